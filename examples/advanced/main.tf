@@ -9,7 +9,7 @@ terraform {
 
 provider "snitchdns" {
   # Using environment variables for security
-  # export SNITCHDNS_API_URL="http://localhost:8000"
+  # export SNITCHDNS_API_URL="http://localhost:8000/api/v1"
   # export SNITCHDNS_API_KEY="your-api-key"
 }
 
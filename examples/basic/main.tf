@@ -9,10 +9,10 @@ terraform {
 
 # Configure the SnitchDNS Provider
 # You can also use environment variables:
-# export SNITCHDNS_API_URL="http://localhost:8000"
+# export SNITCHDNS_API_URL="http://localhost:8000/api/v1"
 # export SNITCHDNS_API_KEY="your-api-key"
 provider "snitchdns" {
-  api_url = "http://localhost:8000"
+  api_url = "http://localhost:8000/api/v1"
   api_key = "your-api-key-here" # Replace with your actual API key
 }
 
@@ -1,7 +1,7 @@
 variable "snitchdns_url" {
   description = "SnitchDNS API URL"
   type        = string
-  default     = "http://localhost:8000"
+  default     = "http://localhost:8000/api/v1"
 }
 
 variable "snitchdns_key" {
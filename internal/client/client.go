@@ -7,10 +7,15 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,6 +23,15 @@ const (
 	emptyJSON = "{}"
 )
 
+// Defaults of a new client.
+const (
+	DefaultTimeout      = 30 * time.Second
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+	DefaultUserAgent    = "terraform-provider-snitchdns/dev"
+)
+
 // Client is the SnitchDNS API client
 type Client struct {
 	BaseURL      string
@@ -27,7 +41,22 @@ type Client struct {
 	MaxRetries   int
 	RetryWaitMin time.Duration
 	RetryWaitMax time.Duration
+	// DebugLogging enables request and response tracing in the
+	// HTTPLogSubsystem tflog subsystem. The API key and credential-like
+	// body fields are masked.
 	DebugLogging bool
+	// MaxConcurrency caps the number of requests in flight. The cap is
+	// lowered automatically while the server answers with 429, 502, 503 or
+	// 504 responses, or 500 responses without a SnitchDNS error, and
+	// recovers with other responses. 0 disables the cap.
+	MaxConcurrency int
+	// RequestsPerSecond limits the request rate, allowing bursts of up to
+	// one second's worth of requests. 0 disables the limit.
+	RequestsPerSecond float64
+
+	throttle      throttle
+	recordTypes   stringListCache
+	recordClasses stringListCache
 }
 
 // NewClient creates a new SnitchDNS API client
@@ -36,23 +65,37 @@ func NewClient(baseURL, apiKey string) *Client {
 		BaseURL: baseURL,
 		APIKey:  apiKey,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
-		UserAgent:    "terraform-provider-snitchdns/dev",
-		MaxRetries:   3,
-		RetryWaitMin: 1 * time.Second,
-		RetryWaitMax: 30 * time.Second,
+		UserAgent:    DefaultUserAgent,
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
 		DebugLogging: false,
+
+		MaxConcurrency: DefaultMaxConcurrency,
 	}
 }
 
-// doRequest performs an HTTP request with authentication
-func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
-	return c.doRequestWithContext(context.Background(), method, path, body)
+// doRequest performs an HTTP request with authentication. ctx bounds the
+// whole call, including every retry and the backoff sleeps between them.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	return c.doRequestReconciled(ctx, method, path, body, nil)
 }
 
-// doRequestWithContext performs an HTTP request with authentication and context
-func (c *Client) doRequestWithContext(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+// reconcileFunc looks for the result of an earlier attempt of a
+// non-idempotent request. It reports whether that attempt took effect.
+type reconcileFunc func(ctx context.Context) (found bool, err error)
+
+// doRequestReconciled performs an HTTP request like doRequest. A failed
+// attempt may still have been applied by the server, e.g. when the response
+// was lost. Before each retry, reconcile is called, if it is set. When it
+// finds the result of an earlier attempt, no retry is made and a nil body is
+// returned, so the caller uses what reconcile found. If reconcile itself
+// fails, the request is not repeated and reconcile is tried again after the
+// next backoff. A 5xx response carrying a SnitchDNS error envelope was
+// handled and rejected by the server, so it does not trigger reconcile.
+func (c *Client) doRequestReconciled(ctx context.Context, method, path string, body interface{}, reconcile reconcileFunc) ([]byte, error) {
 	var jsonData []byte
 	var err error
 
@@ -65,25 +108,53 @@ func (c *Client) doRequestWithContext(ctx context.Context, method, path string,
 
 	// Retry logic
 	var lastErr error
+	var retryAfter, totalWait time.Duration
+	// uncertain is set while an earlier attempt may have been applied by the
+	// server without the client learning about it
+	uncertain := false
 	for attempt := 0; attempt <= c.MaxRetries; attempt++ {
 		if attempt > 0 {
-			// Calculate exponential backoff with jitter
+			// Honour Retry-After if the server sent it, otherwise calculate
+			// exponential backoff with jitter
 			wait := c.calculateBackoff(attempt)
+			if retryAfter > 0 {
+				wait = min(retryAfter, c.RetryWaitMax)
+			}
+			retryAfter = 0
 
-			select {
-			case <-time.After(wait):
-			case <-ctx.Done():
-				return nil, ctx.Err()
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+			totalWait += wait
+
+			if reconcile != nil && uncertain {
+				found, err := reconcile(ctx)
+				if err != nil {
+					if ctx.Err() != nil {
+						return nil, ctx.Err()
+					}
+					lastErr = fmt.Errorf("could not check whether the previous attempt succeeded: %w", err)
+					continue
+				}
+				if found {
+					return nil, nil
+				}
+				uncertain = false
 			}
 		}
 
-		respBody, statusCode, err := c.executeRequest(ctx, method, path, jsonData)
+		respBody, statusCode, header, err := c.executeRequest(ctx, method, path, jsonData, attempt+1)
+		// An HTML page means a misconfigured URL, retrying will not help
+		if errors.Is(err, ErrHTMLResponse) {
+			return nil, err
+		}
 		if err != nil {
 			// Check if error is context-related (don't retry)
 			if ctx.Err() != nil {
 				return nil, ctx.Err()
 			}
 			lastErr = err
+			uncertain = true
 			continue
 		}
 
@@ -92,20 +163,72 @@ func (c *Client) doRequestWithContext(ctx context.Context, method, path string,
 			return respBody, nil
 		}
 
-		// 4xx errors are not retried (client errors)
+		// Rate limited: the request was not processed, retry after the
+		// requested delay
+		if statusCode == http.StatusTooManyRequests {
+			retryAfter = parseRetryAfter(header.Get("Retry-After"), time.Now())
+			lastErr = newAPIError(statusCode, respBody)
+			continue
+		}
+
+		// Other 4xx errors are not retried (client errors)
 		if statusCode >= 400 && statusCode < 500 {
-			return nil, fmt.Errorf("API request failed with status %d: %s", statusCode, string(respBody))
+			return nil, newAPIError(statusCode, respBody)
 		}
 
 		// 5xx errors are retried
-		lastErr = fmt.Errorf("API request failed with status %d: %s", statusCode, string(respBody))
+		if statusCode == http.StatusServiceUnavailable {
+			retryAfter = parseRetryAfter(header.Get("Retry-After"), time.Now())
+		}
+		apiErr := newAPIError(statusCode, respBody)
+		lastErr = apiErr
+		if !apiErr.HasEnvelope() {
+			uncertain = true
+		}
+	}
+
+	return nil, fmt.Errorf("request failed after %d retries, waited %s in total: %w", c.MaxRetries, totalWait.Round(time.Millisecond), lastErr)
+}
+
+// parseRetryAfter parses a Retry-After header value, given either as a
+// number of seconds or as an HTTP date. It returns 0 if the value is absent,
+// invalid or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
 	}
 
-	return nil, fmt.Errorf("request failed after %d retries: %w", c.MaxRetries, lastErr)
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+
+	return 0
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-// executeRequest performs a single HTTP request attempt
-func (c *Client) executeRequest(ctx context.Context, method, path string, jsonData []byte) (respBody []byte, statusCode int, err error) {
+// executeRequest performs a single HTTP request attempt. attempt counts from
+// 1 and is only used for logging.
+func (c *Client) executeRequest(ctx context.Context, method, path string, jsonData []byte, attempt int) (respBody []byte, statusCode int, header http.Header, err error) {
 	var reqBody io.Reader
 	if jsonData != nil {
 		reqBody = bytes.NewBuffer(jsonData)
@@ -113,10 +236,10 @@ func (c *Client) executeRequest(ctx context.Context, method, path string, jsonDa
 
 	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reqBody)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("X-SnitchDNS-Auth", c.APIKey)
+	req.Header.Set(authHeader, c.APIKey)
 	if c.UserAgent != "" {
 		req.Header.Set("User-Agent", c.UserAgent)
 	}
@@ -124,9 +247,20 @@ func (c *Client) executeRequest(ctx context.Context, method, path string, jsonDa
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	epoch, err := c.throttle.acquire(ctx, c)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	defer func() { c.throttle.release(epoch, statusCode, respBody) }()
+
+	ctx = c.logContext(ctx)
+	c.logRequest(ctx, req, attempt, jsonData)
+	start := time.Now()
+	defer func() { c.logResponse(ctx, req, attempt, statusCode, respBody, time.Since(start), err) }()
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		return nil, 0, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -137,10 +271,20 @@ func (c *Client) executeRequest(ctx context.Context, method, path string, jsonDa
 
 	respBody, err = io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp.StatusCode, resp.Header, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	return respBody, resp.StatusCode, nil
+	// Proxies answer 5xx and 429 with HTML error pages too; those stay
+	// retryable server errors
+	if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests && isHTMLResponse(resp.Header, respBody) {
+		return respBody, resp.StatusCode, resp.Header, &HTMLResponseError{
+			StatusCode: resp.StatusCode,
+			URL:        resp.Request.URL.Redacted(),
+			Redirected: resp.Request.URL.String() != req.URL.String(),
+		}
+	}
+
+	return respBody, resp.StatusCode, resp.Header, nil
 }
 
 // calculateBackoff calculates the backoff duration with exponential backoff and jitter
@@ -209,12 +353,41 @@ type UpdateZoneRequest struct {
 	Tags       *string `json:"tags,omitempty"`
 }
 
-// CreateZone creates a new DNS zone
-func (c *Client) CreateZone(req CreateZoneRequest) (*Zone, error) {
-	respBody, err := c.doRequest("POST", "/zones", req)
+// CreateZone creates a new DNS zone. If an attempt fails in a way that leaves
+// it unclear whether the zone was created, the zone is looked up by domain
+// and adopted before the request is retried. The domain is looked up once
+// before the first attempt as well, and a zone that already existed then is
+// never adopted, so creating a duplicate still fails with ErrConflict.
+func (c *Client) CreateZone(ctx context.Context, req CreateZoneRequest) (*Zone, error) {
+	_, err := c.GetZoneByDomain(ctx, req.Domain)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to look up existing zone: %w", err)
+	}
+	existed := err == nil
+
+	var adopted *Zone
+	var reconcile reconcileFunc
+	if !existed {
+		reconcile = func(ctx context.Context) (bool, error) {
+			zone, err := c.GetZoneByDomain(ctx, req.Domain)
+			if errors.Is(err, ErrNotFound) {
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			adopted = zone
+			return true, nil
+		}
+	}
+
+	respBody, err := c.doRequestReconciled(ctx, "POST", "/zones", req, reconcile)
 	if err != nil {
 		return nil, err
 	}
+	if adopted != nil {
+		return adopted, nil
+	}
 
 	var zone Zone
 	if err := json.Unmarshal(respBody, &zone); err != nil {
@@ -224,14 +397,23 @@ func (c *Client) CreateZone(req CreateZoneRequest) (*Zone, error) {
 	return &zone, nil
 }
 
-// GetZone retrieves a zone by ID
-func (c *Client) GetZone(id string) (*Zone, error) {
-	return c.GetZoneWithContext(context.Background(), id)
+// zonePath builds an API path below /zones/{zone}. The zone (an ID or a
+// domain) and every further segment are escaped, so regex domains containing
+// characters such as '?' or '#' stay within their path segment.
+func zonePath(zone string, segments ...string) string {
+	var b strings.Builder
+	b.WriteString("/zones/")
+	b.WriteString(url.PathEscape(zone))
+	for _, segment := range segments {
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	return b.String()
 }
 
-// GetZoneWithContext retrieves a zone by ID with context
-func (c *Client) GetZoneWithContext(ctx context.Context, id string) (*Zone, error) {
-	respBody, err := c.doRequestWithContext(ctx, "GET", fmt.Sprintf("/zones/%s", id), nil)
+// GetZone retrieves a zone by ID
+func (c *Client) GetZone(ctx context.Context, id string) (*Zone, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -244,9 +426,33 @@ func (c *Client) GetZoneWithContext(ctx context.Context, id string) (*Zone, erro
 	return &zone, nil
 }
 
+// GetZoneByDomain retrieves a zone by its domain name
+func (c *Client) GetZoneByDomain(ctx context.Context, domain string) (*Zone, error) {
+	// The server decodes %2F before routing, so a domain containing a slash can
+	// never be addressed as a path segment. Fall back to searching for it.
+	if !strings.Contains(domain, "/") {
+		return c.GetZone(ctx, domain)
+	}
+
+	for zone, err := range c.AllZones(ctx, ListZonesOptions{Search: domain}) {
+		if err != nil {
+			return nil, err
+		}
+		if zone.Domain == domain {
+			return &zone, nil
+		}
+	}
+
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    "Zone not found",
+		Details:    domain,
+	}
+}
+
 // UpdateZone updates an existing zone
-func (c *Client) UpdateZone(id string, req UpdateZoneRequest) (*Zone, error) {
-	respBody, err := c.doRequest("POST", fmt.Sprintf("/zones/%s", id), req)
+func (c *Client) UpdateZone(ctx context.Context, id string, req UpdateZoneRequest) (*Zone, error) {
+	respBody, err := c.doRequest(ctx, "POST", zonePath(id), req)
 	if err != nil {
 		return nil, err
 	}
@@ -260,14 +466,110 @@ func (c *Client) UpdateZone(id string, req UpdateZoneRequest) (*Zone, error) {
 }
 
 // DeleteZone deletes a zone
-func (c *Client) DeleteZone(id string) error {
-	return c.DeleteZoneWithContext(context.Background(), id)
+func (c *Client) DeleteZone(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, "DELETE", zonePath(id), nil)
+	return err
 }
 
-// DeleteZoneWithContext deletes a zone with context
-func (c *Client) DeleteZoneWithContext(ctx context.Context, id string) error {
-	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s", id), nil)
-	return err
+// ListZonesOptions filters and paginates GET /zones
+type ListZonesOptions struct {
+	// Page is the 1-based page number. Defaults to 1.
+	Page int
+	// PerPage is the number of zones per page. Defaults to the server default (50).
+	PerPage int
+	// Search filters zones by domain.
+	Search string
+	// Tags filters zones by any of the given tags.
+	Tags []string
+}
+
+// ZoneList is a single page of zones with its pagination metadata
+type ZoneList struct {
+	Zones   []Zone `json:"data"`
+	Page    int    `json:"page"`
+	Pages   int    `json:"pages"`
+	PerPage int    `json:"per_page"`
+	Total   int    `json:"total"`
+}
+
+// query encodes the options as URL query parameters
+func (o ListZonesOptions) query() url.Values {
+	q := url.Values{}
+	page := o.Page
+	if page < 1 {
+		page = 1
+	}
+	q.Set("page", strconv.Itoa(page))
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Search != "" {
+		q.Set("search", o.Search)
+	}
+	if len(o.Tags) > 0 {
+		q.Set("tags", strings.Join(o.Tags, ","))
+	}
+	return q
+}
+
+// ListZones retrieves a single page of zones
+func (c *Client) ListZones(ctx context.Context, opts ListZonesOptions) (*ZoneList, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/zones?"+opts.query().Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Older servers ignore pagination and return a bare array
+	if trimmed := bytes.TrimSpace(respBody); len(trimmed) > 0 && trimmed[0] == '[' {
+		var zones []Zone
+		if err := json.Unmarshal(trimmed, &zones); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+		return &ZoneList{Zones: zones, Page: 1, Pages: 1, PerPage: len(zones), Total: len(zones)}, nil
+	}
+
+	var list ZoneList
+	if err := json.Unmarshal(respBody, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &list, nil
+}
+
+// AllZones returns an iterator over every zone matching opts, fetching pages
+// on demand. Iteration starts at opts.Page and stops at the last page, when
+// the caller stops ranging, or when ctx is cancelled. Errors are yielded once
+// and end the iteration.
+func (c *Client) AllZones(ctx context.Context, opts ListZonesOptions) iter.Seq2[Zone, error] {
+	return func(yield func(Zone, error) bool) {
+		if opts.Page < 1 {
+			opts.Page = 1
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Zone{}, err)
+				return
+			}
+
+			list, err := c.ListZones(ctx, opts)
+			if err != nil {
+				yield(Zone{}, err)
+				return
+			}
+
+			for _, zone := range list.Zones {
+				if !yield(zone, nil) {
+					return
+				}
+			}
+
+			if len(list.Zones) == 0 || list.Page >= list.Pages {
+				return
+			}
+			opts.Page = list.Page + 1
+		}
+	}
 }
 
 // Record represents a DNS record
@@ -290,6 +592,66 @@ type Record struct {
 	ConditionalData map[string]interface{} `json:"-"`
 }
 
+// decodeData parses the data and conditional_data JSON strings into their maps
+func (r *Record) decodeData() error {
+	// Parse the data JSON string
+	if r.DataRaw != "" {
+		if err := json.Unmarshal([]byte(r.DataRaw), &r.Data); err != nil {
+			return fmt.Errorf("failed to parse data field: %w", err)
+		}
+	}
+
+	// Parse the conditional_data JSON string
+	if r.ConditionalDataRaw != "" && r.ConditionalDataRaw != emptyJSON {
+		if err := json.Unmarshal([]byte(r.ConditionalDataRaw), &r.ConditionalData); err != nil {
+			return fmt.Errorf("failed to parse conditional_data field: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// matches reports whether the record has the content requested by req
+func (r *Record) matches(req CreateRecordRequest) bool {
+	return r.Type == req.Type &&
+		r.Class == req.Class &&
+		r.TTL == req.TTL &&
+		r.Active == req.Active &&
+		r.IsConditional == req.IsConditional &&
+		(!req.IsConditional || r.ConditionalLimit == req.ConditionalLimit && r.ConditionalReset == req.ConditionalReset) &&
+		dataEqual(r.Data, req.Data) &&
+		dataEqual(r.ConditionalData, req.ConditionalData)
+}
+
+// dataEqual compares record data maps by their string representation, as the
+// server may return numeric fields such as an MX priority as numbers.
+func dataEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok || fmt.Sprint(value) != fmt.Sprint(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeRecord parses a single record response body
+func decodeRecord(respBody []byte) (*Record, error) {
+	var record Record
+	if err := json.Unmarshal(respBody, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if err := record.decodeData(); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
 // CreateRecordRequest is the request body for creating a record
 type CreateRecordRequest struct {
 	Active           bool                   `json:"active"`
@@ -318,100 +680,93 @@ type UpdateRecordRequest struct {
 	ConditionalData  map[string]interface{} `json:"conditional_data,omitempty"`
 }
 
-// CreateRecord creates a new DNS record
-func (c *Client) CreateRecord(zoneID string, req CreateRecordRequest) (*Record, error) {
-	respBody, err := c.doRequest("POST", fmt.Sprintf("/zones/%s/records", zoneID), req)
+// CreateRecord creates a new DNS record. If an attempt fails in a way that
+// leaves it unclear whether the record was created, the newest identical
+// record of the zone is adopted instead of retrying the request. Records are
+// not unique, so the zone's records are listed before the first attempt and
+// only records created after that are adopted; an identical record created
+// by hand or owned by another resource is never taken over. Every create
+// therefore costs one more request, and if that listing fails, no record is
+// created and the error is returned.
+func (c *Client) CreateRecord(ctx context.Context, zoneID string, req CreateRecordRequest) (*Record, error) {
+	existing, err := c.ListRecords(ctx, zoneID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to list existing records: %w", err)
 	}
-
-	var record Record
-	if err := json.Unmarshal(respBody, &record); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	known := make(map[int]bool, len(existing))
+	for _, record := range existing {
+		known[record.ID] = true
 	}
 
-	// Parse the data JSON string
-	if record.DataRaw != "" {
-		if err := json.Unmarshal([]byte(record.DataRaw), &record.Data); err != nil {
-			return nil, fmt.Errorf("failed to parse data field: %w", err)
+	var adopted *Record
+	respBody, err := c.doRequestReconciled(ctx, "POST", zonePath(zoneID, "records"), req, func(ctx context.Context) (bool, error) {
+		records, err := c.ListRecords(ctx, zoneID)
+		if err != nil {
+			return false, err
 		}
-	}
-
-	// Parse the conditional_data JSON string
-	if record.ConditionalDataRaw != "" && record.ConditionalDataRaw != emptyJSON {
-		if err := json.Unmarshal([]byte(record.ConditionalDataRaw), &record.ConditionalData); err != nil {
-			return nil, fmt.Errorf("failed to parse conditional_data field: %w", err)
+		for i := range records {
+			if known[records[i].ID] {
+				continue
+			}
+			if records[i].matches(req) && (adopted == nil || records[i].ID > adopted.ID) {
+				adopted = &records[i]
+			}
 		}
+		return adopted != nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if adopted != nil {
+		return adopted, nil
 	}
 
-	return &record, nil
+	return decodeRecord(respBody)
 }
 
 // GetRecord retrieves a record by zone ID and record ID
-func (c *Client) GetRecord(zoneID, recordID string) (*Record, error) {
-	respBody, err := c.doRequest("GET", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), nil)
+func (c *Client) GetRecord(ctx context.Context, zoneID, recordID string) (*Record, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "records", recordID), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var record Record
-	if err := json.Unmarshal(respBody, &record); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	// Parse the data JSON string
-	if record.DataRaw != "" {
-		if err := json.Unmarshal([]byte(record.DataRaw), &record.Data); err != nil {
-			return nil, fmt.Errorf("failed to parse data field: %w", err)
-		}
-	}
-
-	// Parse the conditional_data JSON string
-	if record.ConditionalDataRaw != "" && record.ConditionalDataRaw != emptyJSON {
-		if err := json.Unmarshal([]byte(record.ConditionalDataRaw), &record.ConditionalData); err != nil {
-			return nil, fmt.Errorf("failed to parse conditional_data field: %w", err)
-		}
-	}
-
-	return &record, nil
+	return decodeRecord(respBody)
 }
 
-// UpdateRecord updates an existing DNS record
-func (c *Client) UpdateRecord(zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
-	respBody, err := c.doRequest("POST", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), req)
+// ListRecords retrieves all records of a zone
+func (c *Client) ListRecords(ctx context.Context, zoneID string) ([]Record, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "records"), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var record Record
-	if err := json.Unmarshal(respBody, &record); err != nil {
+	var records []Record
+	if err := json.Unmarshal(respBody, &records); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Parse the data JSON string
-	if record.DataRaw != "" {
-		if err := json.Unmarshal([]byte(record.DataRaw), &record.Data); err != nil {
-			return nil, fmt.Errorf("failed to parse data field: %w", err)
+	for i := range records {
+		if err := records[i].decodeData(); err != nil {
+			return nil, fmt.Errorf("record %d: %w", records[i].ID, err)
 		}
 	}
 
-	// Parse the conditional_data JSON string
-	if record.ConditionalDataRaw != "" && record.ConditionalDataRaw != emptyJSON {
-		if err := json.Unmarshal([]byte(record.ConditionalDataRaw), &record.ConditionalData); err != nil {
-			return nil, fmt.Errorf("failed to parse conditional_data field: %w", err)
-		}
+	return records, nil
+}
+
+// UpdateRecord updates an existing DNS record
+func (c *Client) UpdateRecord(ctx context.Context, zoneID, recordID string, req UpdateRecordRequest) (*Record, error) {
+	respBody, err := c.doRequest(ctx, "POST", zonePath(zoneID, "records", recordID), req)
+	if err != nil {
+		return nil, err
 	}
 
-	return &record, nil
+	return decodeRecord(respBody)
 }
 
 // DeleteRecord deletes a DNS record
-func (c *Client) DeleteRecord(zoneID, recordID string) error {
-	return c.DeleteRecordWithContext(context.Background(), zoneID, recordID)
-}
-
-// DeleteRecordWithContext deletes a DNS record with context
-func (c *Client) DeleteRecordWithContext(ctx context.Context, zoneID, recordID string) error {
-	_, err := c.doRequestWithContext(ctx, "DELETE", fmt.Sprintf("/zones/%s/records/%s", zoneID, recordID), nil)
+func (c *Client) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	_, err := c.doRequest(ctx, "DELETE", zonePath(zoneID, "records", recordID), nil)
 	return err
 }
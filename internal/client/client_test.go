@@ -1,12 +1,21 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 )
 
 const testUserAgent = "terraform-provider-snitchdns/1.0.0"
@@ -33,7 +42,7 @@ func TestRetryLogic(t *testing.T) {
 	client.RetryWaitMin = 10 * time.Millisecond
 	client.RetryWaitMax = 50 * time.Millisecond
 
-	zone, err := client.GetZone("1")
+	zone, err := client.GetZone(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("Expected request to succeed after retries, got error: %v", err)
 	}
@@ -62,7 +71,7 @@ func TestRetryExhausted(t *testing.T) {
 	client.RetryWaitMin = 1 * time.Millisecond
 	client.RetryWaitMax = 5 * time.Millisecond
 
-	_, err := client.GetZone("1")
+	_, err := client.GetZone(context.Background(), "1")
 	if err == nil {
 		t.Fatal("Expected error after exhausting retries")
 	}
@@ -86,7 +95,7 @@ func TestNoRetryOn4xx(t *testing.T) {
 	client := NewClient(server.URL, "test-key")
 	client.MaxRetries = 3
 
-	_, err := client.GetZone("1")
+	_, err := client.GetZone(context.Background(), "1")
 	if err == nil {
 		t.Fatal("Expected error for 404")
 	}
@@ -111,7 +120,7 @@ func TestUserAgentHeader(t *testing.T) {
 	client := NewClient(server.URL, "test-key")
 	client.UserAgent = testUserAgent
 
-	_, err := client.GetZone("1")
+	_, err := client.GetZone(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -134,30 +143,194 @@ func TestContextTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
 
-	_, err := client.GetZoneWithContext(ctx, "1")
+	_, err := client.GetZone(ctx, "1")
 	if err == nil {
 		t.Fatal("Expected timeout error")
 	}
 }
 
-// TestDebugLogging tests that debug logging can be enabled
+// TestContextCancelledDuringBackoff tests that cancelling the context interrupts the sleep between retries
+func TestContextCancelledDuringBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.RetryWaitMin = 10 * time.Second
+	client.RetryWaitMax = 10 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.CreateZone(ctx, CreateZoneRequest{Domain: "example.com"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the backoff sleep to be interrupted, took %s", elapsed)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected 1 request before cancellation, got %d", requests.Load())
+	}
+}
+
+// TestRecordMethodsUseContext tests that every record method passes its context to the request
+func TestRecordMethodsUseContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	calls := map[string]func(context.Context) error{
+		"CreateRecord": func(ctx context.Context) error {
+			_, err := client.CreateRecord(ctx, "1", CreateRecordRequest{})
+			return err
+		},
+		"GetRecord": func(ctx context.Context) error {
+			_, err := client.GetRecord(ctx, "1", "2")
+			return err
+		},
+		"UpdateRecord": func(ctx context.Context) error {
+			_, err := client.UpdateRecord(ctx, "1", "2", UpdateRecordRequest{})
+			return err
+		},
+		"DeleteRecord": func(ctx context.Context) error {
+			return client.DeleteRecord(ctx, "1", "2")
+		},
+		"UpdateZone": func(ctx context.Context) error {
+			_, err := client.UpdateZone(ctx, "1", UpdateZoneRequest{})
+			return err
+		},
+		"DeleteZone": func(ctx context.Context) error {
+			return client.DeleteZone(ctx, "1")
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			if err := call(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected the request to be cancelled, took %s", elapsed)
+			}
+		})
+	}
+}
+
+// TestDebugLogging tests that requests and responses are traced with the API key masked
 func TestDebugLogging(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"id": 1, "domain": "example.com", "active": true, "catch_all": false, "forwarding": false, "regex": false}`))
+		w.Write([]byte(`{"id": 1, "domain": "example.com", "api_key": "server-secret", "note": "test-key-in-body"}`))
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "test-key")
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
 
-	// This test just ensures the client can be configured with debug logging
-	// Actual logging behavior is tested via tflog in provider tests
+	client := NewClient(server.URL, "test-key")
 	client.DebugLogging = true
 
-	_, err := client.GetZone("1")
+	domain := "example.com"
+	if _, err := client.UpdateZone(ctx, "1", UpdateZoneRequest{Domain: &domain}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	raw := output.String()
+	for _, secret := range []string{"test-key", "server-secret"} {
+		if strings.Contains(raw, secret) {
+			t.Errorf("Expected %q to be masked in the log, got:\n%s", secret, raw)
+		}
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
 	if err != nil {
+		t.Fatalf("Failed to decode log: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected a request and a response entry, got %d: %v", len(entries), entries)
+	}
+
+	request, response := entries[0], entries[1]
+	if request["@module"] != "provider."+HTTPLogSubsystem {
+		t.Errorf("Expected subsystem %q, got %v", HTTPLogSubsystem, request["@module"])
+	}
+	if request["method"] != "POST" || request["path"] != "/zones/1" || request["attempt"] != float64(1) {
+		t.Errorf("Unexpected request entry: %v", request)
+	}
+	headers, _ := request["headers"].(map[string]any)
+	if auth := headers[http.CanonicalHeaderKey(authHeader)]; auth != redacted {
+		t.Errorf("Expected %s header to be masked, got %v", authHeader, auth)
+	}
+	if response["status"] != float64(http.StatusOK) || response["latency_ms"] == nil {
+		t.Errorf("Unexpected response entry: %v", response)
+	}
+	if body, _ := response["response_body"].(string); !strings.Contains(body, `"api_key": "***"`) {
+		t.Errorf("Expected api_key to be masked in the response body, got %q", body)
+	}
+}
+
+// TestDebugLoggingDisabled tests that nothing is traced unless DebugLogging is set
+func TestDebugLoggingDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	if _, err := NewClient(server.URL, "test-key").GetZone(ctx, "1"); err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
+	if output.Len() != 0 {
+		t.Errorf("Expected no log output, got:\n%s", output.String())
+	}
+}
+
+// TestRedactBody tests masking and truncation of logged bodies
+func TestRedactBody(t *testing.T) {
+	client := NewClient("http://unused", "abc123")
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty", "", ""},
+		{"no secrets", `{"domain": "example.com"}`, `{"domain": "example.com"}`},
+		{"api key field", `{"api_key":"k1","name":"x"}`, `{"api_key":"***","name":"x"}`},
+		{"token field", `{"Auth-Token" : "a\"b"}`, `{"Auth-Token" : "***"}`},
+		{"non-string secrets", `{"password": 1234, "secret": true, "token": {"value": "t", "ttl": [1, 2]}, "name": "x"}`, `{"password": "***", "secret": "***", "token": "***", "name": "x"}`},
+		{"nested secret", `[{"user": {"apikey": null}}, {"api_key": "k2"}]`, `[{"user": {"apikey": "***"}}, {"api_key": "***"}]`},
+		{"near-miss keys", `{"author": "jane", "tokens_used": 3, "password_hint": "pet"}`, `{"author": "jane", "tokens_used": 3, "password_hint": "pet"}`},
+		{"secret name as value", `{"name": "token", "token": "t"}`, `{"name": "token", "token": "***"}`},
+		{"not json", `<html>token: "t"</html>`, `<html>token: "t"</html>`},
+		{"client key value", `{"data": "abc123"}`, `{"data": "***"}`},
+		{"truncated", strings.Repeat("a", maxLoggedBodyLength+10), strings.Repeat("a", maxLoggedBodyLength) + "... (10 bytes truncated)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.redactBody([]byte(tt.body)); got != tt.want {
+				t.Errorf("redactBody(%q) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
 }
 
 // TestExponentialBackoff tests that retry delays increase exponentially
@@ -182,7 +355,7 @@ func TestExponentialBackoff(t *testing.T) {
 	client.RetryWaitMin = 10 * time.Millisecond
 	client.RetryWaitMax = 100 * time.Millisecond
 
-	_, err := client.GetZone("1")
+	_, err := client.GetZone(context.Background(), "1")
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -208,3 +381,841 @@ func TestExponentialBackoff(t *testing.T) {
 		t.Errorf("Expected exponential backoff, but delay2 (%v) < delay1/2 (%v)", delay2, delay1/2)
 	}
 }
+
+// TestAPIErrorNotFound tests that a 404 is reported as a typed ErrNotFound
+func TestAPIErrorNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success": false, "code": 404, "message": "Not Found", "details": "Zone 404.example.com not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	_, err := client.GetZone(context.Background(), "1")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got: %v", err)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got: %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Success || apiErr.Message != "Not Found" {
+		t.Errorf("Unexpected APIError fields: %+v", apiErr)
+	}
+	if apiErr.Details != "Zone 404.example.com not found" {
+		t.Errorf("Expected details to be decoded, got %q", apiErr.Details)
+	}
+}
+
+// TestAPIErrorSentinels tests the mapping of status codes and error codes to sentinels
+func TestAPIErrorSentinels(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       error
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"success": false, "code": 0, "message": "Access Denied"}`, ErrUnauthorized},
+		{"forbidden", http.StatusForbidden, ``, ErrUnauthorized},
+		{"validation status", http.StatusBadRequest, `{"success": false, "code": 5000, "message": "Missing fields"}`, ErrValidation},
+		{"validation code", http.StatusInternalServerError, `{"success": false, "code": 5005, "message": "Invalid type"}`, ErrValidation},
+		{"conflict status", http.StatusConflict, ``, ErrConflict},
+		{"conflict code", http.StatusBadRequest, `{"success": false, "code": 5003, "message": "Domain already exists"}`, ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := newAPIError(tt.statusCode, []byte(tt.body))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v to match %v", err, tt.want)
+			}
+			if tt.want != ErrNotFound && errors.Is(err, ErrNotFound) {
+				t.Errorf("Did not expect %v to match ErrNotFound", err)
+			}
+		})
+	}
+}
+
+// TestAPIErrorZoneExistsCode tests that of the messages sharing code 5003
+// only "Domain already exists" matches ErrConflict
+func TestAPIErrorZoneExistsCode(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{"Domain already exists", true},
+		{"Could not create zone", false},
+		{"Could not save zone", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			body := fmt.Sprintf(`{"success": false, "code": 5003, "message": %q}`, tt.message)
+			err := newAPIError(http.StatusInternalServerError, []byte(body))
+			if got := errors.Is(err, ErrConflict); got != tt.want {
+				t.Errorf("Expected errors.Is(%v, ErrConflict) = %t", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestAPIErrorNotFoundNotMatchedByDomain tests that a domain containing "404" is not treated as not found
+func TestAPIErrorNotFoundNotMatchedByDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success": false, "code": 5004, "message": "Invalid incoming data", "details": "404.example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	_, err := client.GetZone(context.Background(), "404.example.com")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Did not expect ErrNotFound for a 400 response: %v", err)
+	}
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected ErrValidation, got: %v", err)
+	}
+}
+
+// TestAPIErrorAfterRetries tests that the typed error survives retry exhaustion
+func TestAPIErrorAfterRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"success": false, "code": 5003, "message": "Could not save zone"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxRetries = 1
+	client.RetryWaitMin = 1 * time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+
+	_, err := client.GetZone(context.Background(), "1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError in chain, got: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusInternalServerError || apiErr.Code != CodeZoneExists {
+		t.Errorf("Unexpected APIError fields: %+v", apiErr)
+	}
+}
+
+// zonePageHandler serves numbered zones over the given number of pages and counts requests
+func zonePageHandler(t *testing.T, pages int, requests *atomic.Int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/zones" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil {
+			t.Errorf("Expected numeric page parameter, got %q", r.URL.Query().Get("page"))
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"data": [{"id": %[1]d, "domain": "zone%[1]d-a.example.com"}, {"id": %[2]d, "domain": "zone%[2]d-b.example.com"}], "page": %[3]d, "pages": %[4]d, "per_page": 2, "total": %[5]d}`,
+			page*2-1, page*2, page, pages, pages*2)
+	}
+}
+
+// TestListZones tests that a single page is requested with the given options
+func TestListZones(t *testing.T) {
+	var query url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": [{"id": 7, "domain": "canary.example.com", "tags": ["prod"]}], "page": 2, "pages": 3, "per_page": 1, "total": 3}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	list, err := client.ListZones(context.Background(), ListZonesOptions{
+		Page:    2,
+		PerPage: 1,
+		Search:  "canary",
+		Tags:    []string{"prod", "web"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if query.Get("page") != "2" || query.Get("per_page") != "1" || query.Get("search") != "canary" || query.Get("tags") != "prod,web" {
+		t.Errorf("Unexpected query parameters: %v", query)
+	}
+	if list.Page != 2 || list.Pages != 3 || list.PerPage != 1 || list.Total != 3 {
+		t.Errorf("Unexpected pagination metadata: %+v", list)
+	}
+	if len(list.Zones) != 1 || list.Zones[0].Domain != "canary.example.com" {
+		t.Errorf("Unexpected zones: %+v", list.Zones)
+	}
+}
+
+// TestAllZones tests that the iterator walks every page
+func TestAllZones(t *testing.T) {
+	requests := atomic.Int32{}
+	server := httptest.NewServer(zonePageHandler(t, 3, &requests))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	var ids []int
+	for zone, err := range client.AllZones(context.Background(), ListZonesOptions{PerPage: 2}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, zone.ID)
+	}
+
+	if len(ids) != 6 || ids[0] != 1 || ids[5] != 6 {
+		t.Errorf("Expected zones 1..6, got %v", ids)
+	}
+	if requests.Load() != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests.Load())
+	}
+}
+
+// TestAllZonesStopsEarly tests that no further pages are fetched once the caller stops
+func TestAllZonesStopsEarly(t *testing.T) {
+	requests := atomic.Int32{}
+	server := httptest.NewServer(zonePageHandler(t, 5, &requests))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	for zone, err := range client.AllZones(context.Background(), ListZonesOptions{PerPage: 2}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if zone.ID == 3 {
+			break
+		}
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("Expected 2 page requests, got %d", requests.Load())
+	}
+}
+
+// TestAllZonesContextCancelled tests that the iterator stops when the context is cancelled
+func TestAllZonesContextCancelled(t *testing.T) {
+	requests := atomic.Int32{}
+	server := httptest.NewServer(zonePageHandler(t, 5, &requests))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var lastErr error
+	for zone, err := range client.AllZones(ctx, ListZonesOptions{PerPage: 2}) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		if zone.ID == 2 {
+			cancel()
+		}
+	}
+
+	if !errors.Is(lastErr, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", lastErr)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected 1 page request, got %d", requests.Load())
+	}
+}
+
+// TestListRecords tests that record data strings are decoded for every record in a zone
+func TestListRecords(t *testing.T) {
+	var requestedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[
+			{"id": 1, "zone_id": 5, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.1\"}", "is_conditional": false, "conditional_data": "{}"},
+			{"id": 2, "zone_id": 5, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"10.0.0.2\"}", "is_conditional": true, "conditional_limit": 3, "conditional_data": "{\"address\": \"10.0.0.3\"}"}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	records, err := client.ListRecords(context.Background(), "5")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if requestedPath != "/zones/5/records" {
+		t.Errorf("Unexpected path %s", requestedPath)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Data["address"] != "10.0.0.1" || records[0].ConditionalData != nil {
+		t.Errorf("Unexpected first record: %+v", records[0])
+	}
+	if records[1].Data["address"] != "10.0.0.2" || records[1].ConditionalData["address"] != "10.0.0.3" {
+		t.Errorf("Unexpected second record: %+v", records[1])
+	}
+}
+
+// TestListRecordsInvalidData tests that an undecodable data string is reported
+func TestListRecordsInvalidData(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 9, "zone_id": 5, "type": "A", "data": "not-json"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	_, err := client.ListRecords(context.Background(), "5")
+	if err == nil || !strings.Contains(err.Error(), "failed to parse data field") {
+		t.Errorf("Expected data parse error, got %v", err)
+	}
+}
+
+// TestGetZoneByDomainEscaping tests that regex domains are escaped into a single path segment
+func TestGetZoneByDomainEscaping(t *testing.T) {
+	var escapedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		escapedPath = r.URL.EscapedPath()
+		if r.URL.RawQuery != "" {
+			t.Errorf("Expected no query string, got %q", r.URL.RawQuery)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 3, "domain": "^a?b#c\\.example\\.com$", "regex": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	zone, err := client.GetZoneByDomain(context.Background(), `^a?b#c\.example\.com$`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if escapedPath != "/zones/%5Ea%3Fb%23c%5C.example%5C.com$" {
+		t.Errorf("Unexpected escaped path %q", escapedPath)
+	}
+	if zone.ID != 3 {
+		t.Errorf("Expected zone 3, got %d", zone.ID)
+	}
+}
+
+// TestGetZoneByDomainWithSlash tests that domains containing a slash are resolved by searching
+func TestGetZoneByDomainWithSlash(t *testing.T) {
+	var search string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zones" {
+			t.Errorf("Expected a zone search, got path %q", r.URL.Path)
+		}
+		search = r.URL.Query().Get("search")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": [{"id": 1, "domain": "a/b.example.com.evil"}, {"id": 2, "domain": "a/b.example.com"}], "page": 1, "pages": 1, "per_page": 50, "total": 2}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	zone, err := client.GetZoneByDomain(context.Background(), "a/b.example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if search != "a/b.example.com" {
+		t.Errorf("Unexpected search parameter %q", search)
+	}
+	if zone.ID != 2 {
+		t.Errorf("Expected exact match zone 2, got %d", zone.ID)
+	}
+
+	_, err = client.GetZoneByDomain(context.Background(), "missing/zone")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestRecordPathEscaping tests that record paths escape the zone segment
+func TestRecordPathEscaping(t *testing.T) {
+	var escapedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		escapedPath = r.URL.EscapedPath()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	if _, err := client.ListRecords(context.Background(), "canary?.example.com"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if escapedPath != "/zones/canary%3F.example.com/records" {
+		t.Errorf("Unexpected escaped path %q", escapedPath)
+	}
+}
+
+// dropConnection closes the connection without sending a response, as a
+// proxy or network failure would after the server has handled the request
+func dropConnection(t *testing.T, w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		t.Fatalf("Failed to hijack connection: %v", err)
+	}
+	conn.Close()
+}
+
+// newRetryTestClient creates a client with fast retries for the given server
+func newRetryTestClient(url string) *Client {
+	client := NewClient(url, "test-key")
+	client.MaxRetries = 2
+	client.RetryWaitMin = 1 * time.Millisecond
+	client.RetryWaitMax = 5 * time.Millisecond
+	return client
+}
+
+// TestCreateZoneDroppedResponse tests that a zone created by an attempt whose response was lost is adopted
+func TestCreateZoneDroppedResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		applyFirst  bool
+		wantPosts   int32
+		wantZoneID  int
+		description string
+	}{
+		{"response lost after create", true, 1, 1, "the zone is adopted without a second POST"},
+		{"request lost before create", false, 2, 2, "the request is retried"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			zones := map[string]int{}
+			nextID := 1
+			var posts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/zones":
+					attempt := posts.Add(1)
+					if attempt == 1 && !tt.applyFirst {
+						nextID++
+						dropConnection(t, w)
+						return
+					}
+					if _, exists := zones["example.com"]; exists {
+						w.WriteHeader(http.StatusBadRequest)
+						w.Write([]byte(`{"success": false, "code": 5003, "message": "Domain already exists"}`))
+						return
+					}
+					zones["example.com"] = nextID
+					nextID++
+					if attempt == 1 {
+						dropConnection(t, w)
+						return
+					}
+					fmt.Fprintf(w, `{"id": %d, "domain": "example.com"}`, zones["example.com"])
+				case r.Method == "GET" && r.URL.Path == "/zones/example.com":
+					id, exists := zones["example.com"]
+					if !exists {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					fmt.Fprintf(w, `{"id": %d, "domain": "example.com"}`, id)
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			zone, err := newRetryTestClient(server.URL).CreateZone(context.Background(), CreateZoneRequest{Domain: "example.com"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if zone.ID != tt.wantZoneID {
+				t.Errorf("Expected zone ID %d, got %d", tt.wantZoneID, zone.ID)
+			}
+			if posts.Load() != tt.wantPosts {
+				t.Errorf("Expected %d POST requests (%s), got %d", tt.wantPosts, tt.description, posts.Load())
+			}
+			if len(zones) != 1 {
+				t.Errorf("Expected exactly one zone, got %v", zones)
+			}
+		})
+	}
+}
+
+// TestCreateZoneExistingDomain tests that a zone is never adopted when the
+// server rejects the create, even with a 5xx status, because the domain is taken
+func TestCreateZoneExistingDomain(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+	}{
+		{"existed before the create", true},
+		{"created concurrently", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/zones":
+					w.WriteHeader(http.StatusInternalServerError)
+					w.Write([]byte(`{"success": false, "code": 5003, "message": "Domain already exists"}`))
+				case r.Method == "GET" && r.URL.Path == "/zones/example.com":
+					gets.Add(1)
+					if !tt.existing {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.Write([]byte(`{"id": 7, "domain": "example.com"}`))
+				default:
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			zone, err := newRetryTestClient(server.URL).CreateZone(context.Background(), CreateZoneRequest{Domain: "example.com"})
+			if !errors.Is(err, ErrConflict) {
+				t.Fatalf("Expected ErrConflict, got zone %v and error: %v", zone, err)
+			}
+			if gets.Load() != 1 {
+				t.Errorf("Expected only the lookup before the first attempt, got %d lookups", gets.Load())
+			}
+		})
+	}
+}
+
+// TestCreateRecordDroppedResponse tests that a record created by an attempt whose response was lost is adopted
+func TestCreateRecordDroppedResponse(t *testing.T) {
+	var mu sync.Mutex
+	// An existing record with different data must not be adopted
+	records := []string{`{"id": 1, "zone_id": 1, "active": true, "cls": "IN", "type": "MX", "ttl": 300, "data": "{\"priority\": 20, \"hostname\": \"backup.example.com\"}", "is_conditional": false}`}
+	var posts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/zones/1/records":
+			posts.Add(1)
+			records = append(records, `{"id": 2, "zone_id": 1, "active": true, "cls": "IN", "type": "MX", "ttl": 300, "data": "{\"priority\": 10, \"hostname\": \"mail.example.com\"}", "is_conditional": false}`)
+			dropConnection(t, w)
+		case r.Method == "GET" && r.URL.Path == "/zones/1/records":
+			w.Write([]byte("[" + strings.Join(records, ",") + "]"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	record, err := newRetryTestClient(server.URL).CreateRecord(context.Background(), "1", CreateRecordRequest{
+		Active: true,
+		Class:  "IN",
+		Type:   "MX",
+		TTL:    300,
+		Data:   map[string]interface{}{"priority": "10", "hostname": "mail.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.ID != 2 {
+		t.Errorf("Expected the new record 2 to be adopted, got %d", record.ID)
+	}
+	if posts.Load() != 1 || len(records) != 2 {
+		t.Errorf("Expected 1 POST and 2 records, got %d POSTs and %d records", posts.Load(), len(records))
+	}
+}
+
+// TestCreateRecordIgnoresExistingIdentical tests that an identical record that existed before the create is never adopted
+func TestCreateRecordIgnoresExistingIdentical(t *testing.T) {
+	const existing = `{"id": 1, "zone_id": 1, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"192.0.2.1\"}", "is_conditional": false}`
+	var posts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/zones/1/records":
+			// The first attempt is lost before the server creates anything
+			if posts.Add(1) == 1 {
+				dropConnection(t, w)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 2, "zone_id": 1, "active": true, "cls": "IN", "type": "A", "ttl": 300, "data": "{\"address\": \"192.0.2.1\"}", "is_conditional": false}`))
+		case r.Method == "GET" && r.URL.Path == "/zones/1/records":
+			w.Write([]byte("[" + existing + "]"))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	record, err := newRetryTestClient(server.URL).CreateRecord(context.Background(), "1", CreateRecordRequest{
+		Active: true,
+		Class:  "IN",
+		Type:   "A",
+		TTL:    300,
+		Data:   map[string]interface{}{"address": "192.0.2.1"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.ID != 2 {
+		t.Errorf("Expected the new record 2, got %d", record.ID)
+	}
+	if posts.Load() != 2 {
+		t.Errorf("Expected the create to be retried, got %d POSTs", posts.Load())
+	}
+}
+
+// TestCreateRecordListFails tests that no record is posted when the records
+// of the zone cannot be listed before the first attempt
+func TestCreateRecordListFails(t *testing.T) {
+	var posts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"success": false, "code": 0, "message": "Access denied"}`))
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(server.URL).CreateRecord(context.Background(), "1", CreateRecordRequest{Type: "A"})
+	if err == nil || !strings.Contains(err.Error(), "failed to list existing records") {
+		t.Errorf("Expected listing error, got: %v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected the API error to be wrapped, got: %v", err)
+	}
+	if posts.Load() != 0 {
+		t.Errorf("Expected no POST, got %d", posts.Load())
+	}
+}
+
+// TestCreateNotRepostedWhenReconcileFails tests that a create is not repeated while its outcome is unknown
+func TestCreateNotRepostedWhenReconcileFails(t *testing.T) {
+	var posts, gets atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts.Add(1)
+			dropConnection(t, w)
+			return
+		}
+		// Only the listing before the first attempt succeeds
+		if gets.Add(1) == 1 {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := newRetryTestClient(server.URL).CreateRecord(context.Background(), "1", CreateRecordRequest{Type: "A"})
+	if err == nil || !strings.Contains(err.Error(), "could not check whether the previous attempt succeeded") {
+		t.Errorf("Expected reconcile error, got: %v", err)
+	}
+	if posts.Load() != 1 {
+		t.Errorf("Expected a single POST, got %d", posts.Load())
+	}
+}
+
+// TestParseRetryAfter tests both forms of the Retry-After header
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{" 0 ", 0},
+		{"-5", 0},
+		{"soon", 0},
+		{"Wed, 01 May 2024 10:00:30 GMT", 30 * time.Second},
+		{"Wed, 01 May 2024 09:59:00 GMT", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestRetryAfter tests that 429 and 503 responses are retried after the requested delay
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter func() string
+		wantWait   time.Duration
+	}{
+		{"429 seconds", http.StatusTooManyRequests, func() string { return "1" }, 1 * time.Second},
+		{"503 seconds", http.StatusServiceUnavailable, func() string { return "1" }, 1 * time.Second},
+		{"429 capped by RetryWaitMax", http.StatusTooManyRequests, func() string { return "3600" }, 1500 * time.Millisecond},
+		{"429 HTTP date", http.StatusTooManyRequests, func() string {
+			return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat)
+		}, 1 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if attempts.Add(1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter())
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-key")
+			client.RetryWaitMin = 1 * time.Millisecond
+			client.RetryWaitMax = 1500 * time.Millisecond
+
+			start := time.Now()
+			if _, err := client.GetZone(context.Background(), "1"); err != nil {
+				t.Fatalf("Expected request to succeed after retry, got: %v", err)
+			}
+			elapsed := time.Since(start)
+			if elapsed < tt.wantWait || elapsed > tt.wantWait+time.Second {
+				t.Errorf("Expected to wait about %s, waited %s", tt.wantWait, elapsed)
+			}
+			if attempts.Load() != 2 {
+				t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+			}
+		})
+	}
+}
+
+// TestRetryAfterExhausted tests that the final error reports the total time waited
+func TestRetryAfterExhausted(t *testing.T) {
+	var posts, gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			// Only the lookup before the first attempt is expected
+			if gets.Add(1) > 1 {
+				t.Errorf("Expected no reconcile lookups for rate limited creates, got %s %s", r.Method, r.URL.Path)
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		posts.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("rate limited"))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+	client.RetryWaitMin = 20 * time.Millisecond
+	client.RetryWaitMax = 20 * time.Millisecond
+
+	_, err := client.CreateZone(context.Background(), CreateZoneRequest{Domain: "example.com"})
+	if err == nil || !strings.Contains(err.Error(), "waited ") || !strings.Contains(err.Error(), "in total") {
+		t.Fatalf("Expected error reporting the total wait, got: %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected 429 APIError in chain, got: %v", err)
+	}
+	if posts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", posts.Load())
+	}
+}
+
+// TestHTMLResponse tests that HTML pages from the web UI are reported as a
+// misconfigured URL instead of a parse error or a missing object
+func TestHTMLResponse(t *testing.T) {
+	var requests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/zones/1", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Redirect(w, r, "/auth/login?next=%2Fzones%2F1", http.StatusFound)
+	})
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte("<!DOCTYPE html><html><body>Login</body></html>"))
+	})
+	mux.HandleFunc("/zones", func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("<html><body>Not Found</body></html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL)
+
+	_, err := client.GetZone(context.Background(), "1")
+	if !errors.Is(err, ErrHTMLResponse) || !strings.Contains(err.Error(), "login page") || !strings.Contains(err.Error(), "/api/v1") {
+		t.Errorf("Expected a redirect to the login page to be reported, got: %v", err)
+	}
+
+	_, err = client.CreateZone(context.Background(), CreateZoneRequest{Domain: "example.com"})
+	if !errors.Is(err, ErrHTMLResponse) || !strings.Contains(err.Error(), "HTML page (status 404") {
+		t.Errorf("Expected an HTML 404 to be reported, got: %v", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("Expected an HTML 404 not to match ErrNotFound")
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("Expected HTML responses not to be retried, got %d requests", requests.Load())
+	}
+}
+
+// TestIsHTMLResponse tests detection by Content-Type and by body
+func TestIsHTMLResponse(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		want        bool
+	}{
+		{"application/json", `{"id": 1}`, false},
+		{"application/json", "<html>", false},
+		{"text/html; charset=utf-8", "", true},
+		{"application/xhtml+xml", "", true},
+		{"", "  <!DOCTYPE html><html>", true},
+		{"", "<HTML><body>", true},
+		{"", `{"id": 1}`, false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.contentType != "" {
+			header.Set("Content-Type", tt.contentType)
+		}
+		if got := isHTMLResponse(header, []byte(tt.body)); got != tt.want {
+			t.Errorf("isHTMLResponse(%q, %q) = %t, want %t", tt.contentType, tt.body, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that an *APIError matches via errors.Is.
+var (
+	// ErrNotFound indicates the requested object does not exist (HTTP 404).
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized indicates a missing, invalid or insufficient API key (HTTP 401/403).
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrValidation indicates the API rejected the request data.
+	ErrValidation = errors.New("validation failed")
+	// ErrConflict indicates the object clashes with an existing one.
+	ErrConflict = errors.New("conflict")
+	// ErrHTMLResponse indicates the server answered with an HTML page instead
+	// of JSON, which usually means the base URL points at the web UI.
+	ErrHTMLResponse = errors.New("HTML response")
+)
+
+// SnitchDNS error codes as documented in API_SPEC.md.
+const (
+	CodeMissingFields           = 5000
+	CodeEmptyDomain             = 5001
+	CodeTagsNotSaved            = 5002
+	CodeZoneExists              = 5003
+	CodeInvalidData             = 5004
+	CodeInvalidFieldValue       = 5005
+	CodeInvalidNotificationType = 5006
+	CodeInvalidSubscription     = 5007
+	CodeNoData                  = 5008
+	CodeNotificationDisabled    = 5009
+)
+
+// APIError is returned when the SnitchDNS API responds with a non-2xx status.
+// It carries the HTTP status together with the fields of the SnitchDNS error
+// envelope, when the response body contained one.
+type APIError struct {
+	StatusCode int
+	Success    bool
+	Code       int
+	Message    string
+	Details    string
+
+	// Body is the raw response body, kept for responses without an envelope.
+	Body string
+}
+
+// errorEnvelope mirrors the SnitchDNS error response body.
+type errorEnvelope struct {
+	Success *bool           `json:"success"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Details json.RawMessage `json:"details"`
+}
+
+// newAPIError builds an APIError from a status code and response body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Success == nil {
+		return apiErr
+	}
+
+	apiErr.Success = *envelope.Success
+	apiErr.Code = envelope.Code
+	apiErr.Message = envelope.Message
+
+	// details is usually a string, but fall back to the raw JSON otherwise
+	if len(envelope.Details) > 0 && string(envelope.Details) != "null" {
+		var details string
+		if err := json.Unmarshal(envelope.Details, &details); err == nil {
+			apiErr.Details = details
+		} else {
+			apiErr.Details = string(envelope.Details)
+		}
+	}
+
+	return apiErr
+}
+
+// HasEnvelope reports whether the response body contained a SnitchDNS error envelope.
+func (e *APIError) HasEnvelope() bool {
+	return e.Code != 0 || e.Message != ""
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if !e.HasEnvelope() {
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+	}
+
+	msg := fmt.Sprintf("API request failed with status %d (code %d): %s", e.StatusCode, e.Code, e.Message)
+	if e.Details != "" {
+		msg += ": " + e.Details
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the package sentinels.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		// SnitchDNS uses 5003 both for "Domain already exists" and for
+		// "Could not create/save zone", a failure to store the zone, so only
+		// the former is a conflict
+		return e.StatusCode == http.StatusConflict ||
+			(e.Code == CodeZoneExists && strings.Contains(strings.ToLower(e.Message), "exist"))
+	case ErrValidation:
+		if e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity {
+			return true
+		}
+		switch e.Code {
+		case CodeMissingFields, CodeEmptyDomain, CodeInvalidData, CodeInvalidFieldValue,
+			CodeInvalidNotificationType, CodeInvalidSubscription, CodeNoData:
+			return true
+		}
+	}
+	return false
+}
+
+// HTMLResponseError is returned when the server answers with an HTML page
+// instead of JSON. This happens when the base URL lacks the /api/v1 path, in
+// which case the web UI answers or redirects to its login page. It never
+// matches ErrNotFound, so that resources are not dropped from state because
+// of a misconfigured URL.
+type HTMLResponseError struct {
+	StatusCode int
+	// URL is the URL that answered, after following redirects
+	URL string
+	// Redirected reports whether the request was redirected to URL
+	Redirected bool
+}
+
+// Error implements the error interface.
+func (e *HTMLResponseError) Error() string {
+	if e.Redirected && strings.Contains(strings.ToLower(e.URL), "login") {
+		return fmt.Sprintf("the request was redirected to the login page %s of the SnitchDNS web UI instead of being answered by the API. "+
+			"The API URL probably lacks the /api/v1 path, e.g. https://dns.example.com/api/v1", e.URL)
+	}
+	return fmt.Sprintf("the server answered with an HTML page (status %d from %s) instead of JSON. "+
+		"The API URL probably does not point at the SnitchDNS API; it usually ends in /api/v1, e.g. https://dns.example.com/api/v1", e.StatusCode, e.URL)
+}
+
+// Is reports whether the error matches ErrHTMLResponse.
+func (e *HTMLResponseError) Is(target error) bool {
+	return target == ErrHTMLResponse
+}
+
+// isHTMLResponse reports whether a response is an HTML page, judged by its
+// Content-Type or, if that is missing, by the start of the body
+func isHTMLResponse(header http.Header, body []byte) bool {
+	if contentType := header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+	}
+
+	start := strings.ToLower(strings.TrimSpace(string(body[:min(len(body), 64)])))
+	return strings.HasPrefix(start, "<!doctype html") || strings.HasPrefix(start, "<html")
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// HTTPLogSubsystem is the tflog subsystem the client traces requests in. Its
+// level follows TF_LOG_PROVIDER and can be set separately with
+// TF_LOG_PROVIDER_SNITCHDNS_HTTP.
+const HTTPLogSubsystem = "snitchdns_http"
+
+// maxLoggedBodyLength is the number of bytes of a body that are logged
+const maxLoggedBodyLength = 2048
+
+const redacted = "***"
+
+// authHeader is the header carrying the API key
+const authHeader = "X-SnitchDNS-Auth"
+
+// secretJSONKeys are the names of JSON members holding credentials, in lower
+// case with '-' written as '_'. Their values are masked whatever their type.
+var secretJSONKeys = map[string]bool{
+	"api_key":          true,
+	"apikey":           true,
+	"auth":             true,
+	"auth_token":       true,
+	"authorization":    true,
+	"x_snitchdns_auth": true,
+	"token":            true,
+	"access_token":     true,
+	"refresh_token":    true,
+	"secret":           true,
+	"client_secret":    true,
+	"password":         true,
+}
+
+// logContext returns ctx with the HTTP trace subsystem set up, if
+// DebugLogging is enabled. The API key is masked in every field, in addition
+// to the redaction done by the callers, so that it cannot leak through a
+// field added later.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	if !c.DebugLogging {
+		return ctx
+	}
+
+	ctx = tflog.NewSubsystem(ctx, HTTPLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER", strings.ToUpper(HTTPLogSubsystem)))
+	if c.APIKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, HTTPLogSubsystem, c.APIKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, HTTPLogSubsystem, c.APIKey)
+	}
+	return ctx
+}
+
+// logRequest traces a request that is about to be sent
+func (c *Client) logRequest(ctx context.Context, req *http.Request, attempt int, body []byte) {
+	if !c.DebugLogging {
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "Sending SnitchDNS API request", map[string]any{
+		"method":       req.Method,
+		"path":         req.URL.RequestURI(),
+		"attempt":      attempt,
+		"headers":      redactHeaders(req.Header),
+		"request_body": c.redactBody(body),
+	})
+}
+
+// logResponse traces the outcome of a request. err is set if no response
+// was received or its body could not be read.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, attempt int, statusCode int, body []byte, latency time.Duration, err error) {
+	if !c.DebugLogging {
+		return
+	}
+
+	fields := map[string]any{
+		"method":     req.Method,
+		"path":       req.URL.RequestURI(),
+		"attempt":    attempt,
+		"latency_ms": latency.Milliseconds(),
+	}
+	if statusCode != 0 {
+		fields["status"] = statusCode
+		fields["response_body"] = c.redactBody(body)
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "SnitchDNS API request failed", fields)
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, HTTPLogSubsystem, "Received SnitchDNS API response", fields)
+}
+
+// redactHeaders returns the headers as a map for logging, with the API key
+// masked
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if strings.EqualFold(name, authHeader) {
+			headers[name] = redacted
+			continue
+		}
+		headers[name] = strings.Join(values, ", ")
+	}
+	return headers
+}
+
+// redactBody prepares a body for logging. Credential JSON members and the
+// API key are masked, and the result is truncated to maxLoggedBodyLength
+// bytes.
+func (c *Client) redactBody(body []byte) string {
+	s := string(redactJSONSecrets(body))
+	if c.APIKey != "" {
+		s = strings.ReplaceAll(s, c.APIKey, redacted)
+	}
+
+	if len(s) <= maxLoggedBodyLength {
+		return s
+	}
+
+	cut := maxLoggedBodyLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... (%d bytes truncated)", s[:cut], len(s)-cut)
+}
+
+// redactJSONSecrets replaces the values of the members named in
+// secretJSONKeys, at any depth, with a masked string. The rest of the body is
+// left as it is, so the log shows what was sent. Bodies that are not JSON are
+// returned unchanged; anything found before a syntax error is still masked.
+func redactJSONSecrets(body []byte) []byte {
+	type frame struct {
+		object bool
+		key    bool
+	}
+	var stack []frame
+	var spans [][2]int
+
+	// valueDone moves an enclosing object on to its next key
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].key = true
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+
+		if name, ok := token.(string); ok && len(stack) > 0 && stack[len(stack)-1].key {
+			if !secretJSONKeys[strings.ReplaceAll(strings.ToLower(name), "-", "_")] {
+				stack[len(stack)-1].key = false
+				continue
+			}
+			offset := int(decoder.InputOffset())
+			var value json.RawMessage
+			if err := decoder.Decode(&value); err != nil {
+				break
+			}
+			start := offset + bytes.Index(body[offset:], value)
+			spans = append(spans, [2]int{start, start + len(value)})
+			continue
+		}
+
+		switch token {
+		case json.Delim('{'):
+			stack = append(stack, frame{object: true, key: true})
+		case json.Delim('['):
+			stack = append(stack, frame{})
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			valueDone()
+		default:
+			valueDone()
+		}
+	}
+
+	if len(spans) == 0 {
+		return body
+	}
+
+	var b bytes.Buffer
+	last := 0
+	for _, span := range spans {
+		b.Write(body[last:span[0]])
+		b.WriteString(`"` + redacted + `"`)
+		last = span[1]
+	}
+	b.Write(body[last:])
+	return b.Bytes()
+}
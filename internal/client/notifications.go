@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// NotificationProvider represents a notification channel of the server
+type NotificationProvider struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// NotificationSubscription represents the subscription of a zone to a notification provider
+type NotificationSubscription struct {
+	ZoneID  int    `json:"zone_id"`
+	TypeID  int    `json:"type_id"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+
+	// Data is provider specific: a list of addresses for email, a string or
+	// an object for other providers.
+	Data json.RawMessage `json:"data"`
+}
+
+// UpdateNotificationRequest is the request body for updating a notification subscription
+type UpdateNotificationRequest struct {
+	Enabled *bool       `json:"enabled,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// ListNotificationProviders retrieves the notification providers known to the server
+func (c *Client) ListNotificationProviders(ctx context.Context) ([]NotificationProvider, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/notifications/providers", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var providers []NotificationProvider
+	if err := json.Unmarshal(respBody, &providers); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return providers, nil
+}
+
+// ListNotifications retrieves all notification subscriptions of a zone
+func (c *Client) ListNotifications(ctx context.Context, zoneID string) ([]NotificationSubscription, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "notifications"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []NotificationSubscription
+	if err := json.Unmarshal(respBody, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetNotification retrieves the subscription of a zone to the named notification provider
+func (c *Client) GetNotification(ctx context.Context, zoneID, provider string) (*NotificationSubscription, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "notifications", provider), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNotification(respBody)
+}
+
+// UpdateNotification updates the subscription of a zone to the named notification provider.
+// The API has no separate create or delete; subscriptions are enabled and disabled instead.
+func (c *Client) UpdateNotification(ctx context.Context, zoneID, provider string, req UpdateNotificationRequest) (*NotificationSubscription, error) {
+	respBody, err := c.doRequest(ctx, "POST", zonePath(zoneID, "notifications", provider), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNotification(respBody)
+}
+
+// decodeNotification parses a single notification subscription response body
+func decodeNotification(respBody []byte) (*NotificationSubscription, error) {
+	var subscription NotificationSubscription
+	if err := json.Unmarshal(respBody, &subscription); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestNotificationSubscriptions tests reading and updating subscriptions with provider specific data
+func TestNotificationSubscriptions(t *testing.T) {
+	var updated map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/zones/2/notifications":
+			w.Write([]byte(`[
+				{"zone_id": 2, "type_id": 1, "type": "email", "enabled": true, "data": ["soc@example.com"]},
+				{"zone_id": 2, "type_id": 3, "type": "webhook", "enabled": false, "data": "https://hooks.example.com/x"}
+			]`))
+		case r.Method == "GET" && r.URL.Path == "/zones/2/notifications/email":
+			w.Write([]byte(`{"zone_id": 2, "type_id": 1, "type": "email", "enabled": true, "data": ["soc@example.com"]}`))
+		case r.Method == "POST" && r.URL.Path == "/zones/2/notifications/email":
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.Write([]byte(`{"zone_id": 2, "type_id": 1, "type": "email", "enabled": true, "data": ["soc@example.com", "cert@example.com"]}`))
+		case r.URL.Path == "/zones/2/notifications/pager":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"success": false, "code": 5006, "message": "Invalid notification type"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := context.Background()
+
+	subscriptions, err := client.ListNotifications(ctx, "2")
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(subscriptions) != 2 || string(subscriptions[1].Data) != `"https://hooks.example.com/x"` {
+		t.Errorf("Unexpected subscriptions: %+v", subscriptions)
+	}
+
+	subscription, err := client.GetNotification(ctx, "2", "email")
+	if err != nil {
+		t.Fatalf("GetNotification: %v", err)
+	}
+	if string(subscription.Data) != `["soc@example.com"]` {
+		t.Errorf("Unexpected data %s", subscription.Data)
+	}
+
+	enabled := true
+	subscription, err = client.UpdateNotification(ctx, "2", "email", UpdateNotificationRequest{
+		Enabled: &enabled,
+		Data:    []string{"soc@example.com", "cert@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateNotification: %v", err)
+	}
+	if data, ok := updated["data"].([]interface{}); !ok || len(data) != 2 {
+		t.Errorf("Expected data list in request body, got %v", updated)
+	}
+	if !subscription.Enabled {
+		t.Errorf("Expected enabled subscription, got %+v", subscription)
+	}
+
+	_, err = client.GetNotification(ctx, "2", "pager")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != CodeInvalidNotificationType {
+		t.Errorf("Expected code %d, got %v", CodeInvalidNotificationType, err)
+	}
+}
+
+// TestListNotificationProviders tests decoding of the global provider list
+func TestListNotificationProviders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/notifications/providers" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id": 1, "name": "email", "enabled": false}, {"id": 3, "name": "webhook", "enabled": true}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	providers, err := client.ListNotificationProviders(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(providers) != 2 || providers[0].Enabled || !providers[1].Enabled || providers[1].Name != "webhook" || providers[1].ID != 3 {
+		t.Errorf("Unexpected providers: %+v", providers)
+	}
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// stringListCache holds a list fetched from the API once per client. Failed
+// fetches are not cached so that a later call can try again.
+type stringListCache struct {
+	mu     sync.Mutex
+	values []string
+}
+
+// get returns the cached list, fetching it from path on first use
+func (s *stringListCache) get(ctx context.Context, c *Client, path string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.values != nil {
+		return slices.Clone(s.values), nil
+	}
+
+	respBody, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	if err := json.Unmarshal(respBody, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if values == nil {
+		values = []string{}
+	}
+
+	s.values = values
+	return slices.Clone(values), nil
+}
+
+// RecordTypes retrieves the record types supported by the server, e.g. A or
+// TXT. The list is fetched once and cached for the lifetime of the client.
+func (c *Client) RecordTypes(ctx context.Context) ([]string, error) {
+	return c.recordTypes.get(ctx, c, "/records/types")
+}
+
+// RecordClasses retrieves the record classes supported by the server, e.g.
+// IN. The list is fetched once and cached for the lifetime of the client.
+func (c *Client) RecordClasses(ctx context.Context) ([]string, error) {
+	return c.recordClasses.get(ctx, c, "/records/classes")
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// TestRecordTypesAndClassesCached tests that the lists are fetched once per client
+func TestRecordTypesAndClassesCached(t *testing.T) {
+	requests := map[string]int{}
+	fail := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/records/types":
+			w.Write([]byte(`["A", "AAAA", "TXT", "HTTPS"]`))
+		case "/records/classes":
+			if fail {
+				fail = false
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`["IN", "CH"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := context.Background()
+
+	for range 3 {
+		types, err := client.RecordTypes(ctx)
+		if err != nil {
+			t.Fatalf("RecordTypes: %v", err)
+		}
+		if !slices.Contains(types, "HTTPS") {
+			t.Errorf("Expected HTTPS in %v", types)
+		}
+		// Callers must not be able to modify the cache
+		types[0] = "X"
+	}
+	if requests["/records/types"] != 1 {
+		t.Errorf("Expected 1 request for types, got %d", requests["/records/types"])
+	}
+	if types, _ := client.RecordTypes(ctx); types[0] != "A" {
+		t.Errorf("Expected cached list to be unaffected by callers, got %v", types)
+	}
+
+	// Errors are not cached
+	if _, err := client.RecordClasses(ctx); err == nil {
+		t.Fatal("Expected error on first RecordClasses call")
+	}
+	for range 2 {
+		classes, err := client.RecordClasses(ctx)
+		if err != nil {
+			t.Fatalf("RecordClasses: %v", err)
+		}
+		if !slices.Equal(classes, []string{"IN", "CH"}) {
+			t.Errorf("Unexpected classes %v", classes)
+		}
+	}
+	if requests["/records/classes"] != 2 {
+		t.Errorf("Expected 2 requests for classes, got %d", requests["/records/classes"])
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Restriction types accepted by the API
+const (
+	RestrictionTypeAllow = "allow"
+	RestrictionTypeBlock = "block"
+)
+
+// Restriction represents an IP allow/block entry of a zone
+type Restriction struct {
+	ID      int    `json:"id,omitempty"`
+	ZoneID  int    `json:"zone_id,omitempty"`
+	IP      string `json:"ip"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+// CreateRestrictionRequest is the request body for creating a restriction
+type CreateRestrictionRequest struct {
+	Type      string `json:"type"`
+	Enabled   bool   `json:"enabled"`
+	IPOrRange string `json:"ip_or_range"`
+}
+
+// UpdateRestrictionRequest is the request body for updating a restriction
+type UpdateRestrictionRequest struct {
+	Type      *string `json:"type,omitempty"`
+	Enabled   *bool   `json:"enabled,omitempty"`
+	IPOrRange *string `json:"ip_or_range,omitempty"`
+}
+
+// ListRestrictions retrieves all restrictions of a zone
+func (c *Client) ListRestrictions(ctx context.Context, zoneID string) ([]Restriction, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "restrictions"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var restrictions []Restriction
+	if err := json.Unmarshal(respBody, &restrictions); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return restrictions, nil
+}
+
+// CreateRestriction creates a new restriction in a zone
+func (c *Client) CreateRestriction(ctx context.Context, zoneID string, req CreateRestrictionRequest) (*Restriction, error) {
+	respBody, err := c.doRequest(ctx, "POST", zonePath(zoneID, "restrictions"), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRestriction(respBody)
+}
+
+// GetRestriction retrieves a restriction by zone ID and restriction ID
+func (c *Client) GetRestriction(ctx context.Context, zoneID, restrictionID string) (*Restriction, error) {
+	respBody, err := c.doRequest(ctx, "GET", zonePath(zoneID, "restrictions", restrictionID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRestriction(respBody)
+}
+
+// UpdateRestriction updates an existing restriction
+func (c *Client) UpdateRestriction(ctx context.Context, zoneID, restrictionID string, req UpdateRestrictionRequest) (*Restriction, error) {
+	respBody, err := c.doRequest(ctx, "POST", zonePath(zoneID, "restrictions", restrictionID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRestriction(respBody)
+}
+
+// DeleteRestriction deletes a restriction
+func (c *Client) DeleteRestriction(ctx context.Context, zoneID, restrictionID string) error {
+	_, err := c.doRequest(ctx, "DELETE", zonePath(zoneID, "restrictions", restrictionID), nil)
+	return err
+}
+
+// decodeRestriction parses a single restriction response body
+func decodeRestriction(respBody []byte) (*Restriction, error) {
+	var restriction Restriction
+	if err := json.Unmarshal(respBody, &restriction); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &restriction, nil
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRestrictionCRUD tests the restriction endpoints and request bodies
+func TestRestrictionCRUD(t *testing.T) {
+	var requests []string
+	var created CreateRestrictionRequest
+	var updated map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/zones/4/restrictions":
+			json.NewDecoder(r.Body).Decode(&created)
+			w.Write([]byte(`{"id": 11, "zone_id": 4, "ip": "10.0.0.0/8", "type": "allow", "enabled": true}`))
+		case r.Method == "GET" && r.URL.Path == "/zones/4/restrictions":
+			w.Write([]byte(`[{"id": 11, "zone_id": 4, "ip": "10.0.0.0/8", "type": "allow", "enabled": true}]`))
+		case r.Method == "GET" && r.URL.Path == "/zones/4/restrictions/11":
+			w.Write([]byte(`{"id": 11, "zone_id": 4, "ip": "10.0.0.0/8", "type": "allow", "enabled": true}`))
+		case r.Method == "POST" && r.URL.Path == "/zones/4/restrictions/11":
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.Write([]byte(`{"id": 11, "zone_id": 4, "ip": "10.0.0.0/8", "type": "block", "enabled": false}`))
+		case r.Method == "DELETE" && r.URL.Path == "/zones/4/restrictions/11":
+			w.Write([]byte(`{"success": true, "message": "OK"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	ctx := context.Background()
+
+	restriction, err := client.CreateRestriction(ctx, "4", CreateRestrictionRequest{
+		Type:      RestrictionTypeAllow,
+		Enabled:   true,
+		IPOrRange: "10.0.0.0/8",
+	})
+	if err != nil {
+		t.Fatalf("CreateRestriction: %v", err)
+	}
+	if restriction.ID != 11 || restriction.IP != "10.0.0.0/8" || created.IPOrRange != "10.0.0.0/8" {
+		t.Errorf("Unexpected create result %+v (request %+v)", restriction, created)
+	}
+
+	restrictions, err := client.ListRestrictions(ctx, "4")
+	if err != nil || len(restrictions) != 1 {
+		t.Fatalf("ListRestrictions: %v %+v", err, restrictions)
+	}
+
+	if _, err := client.GetRestriction(ctx, "4", "11"); err != nil {
+		t.Fatalf("GetRestriction: %v", err)
+	}
+
+	blockType := RestrictionTypeBlock
+	enabled := false
+	restriction, err = client.UpdateRestriction(ctx, "4", "11", UpdateRestrictionRequest{Type: &blockType, Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("UpdateRestriction: %v", err)
+	}
+	if restriction.Type != RestrictionTypeBlock || restriction.Enabled {
+		t.Errorf("Unexpected update result %+v", restriction)
+	}
+	if _, ok := updated["ip_or_range"]; ok {
+		t.Errorf("Expected ip_or_range to be omitted from the update body, got %v", updated)
+	}
+
+	if err := client.DeleteRestriction(ctx, "4", "11"); err != nil {
+		t.Fatalf("DeleteRestriction: %v", err)
+	}
+
+	if _, err := client.GetRestriction(ctx, "4", "12"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	if len(requests) != 6 {
+		t.Errorf("Expected 6 requests, got %v", requests)
+	}
+}
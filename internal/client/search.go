@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SearchOptions filters and paginates GET /search. Empty fields and nil
+// pointers are not sent, leaving the filter unrestricted.
+type SearchOptions struct {
+	// Page is the 1-based page number. Defaults to 1.
+	Page int
+	// PerPage is the number of hits per page. Defaults to the server default.
+	PerPage int
+
+	Domain   string
+	SourceIP string
+	Type     string
+	Class    string
+
+	Matched   *bool
+	Forwarded *bool
+	Blocked   *bool
+
+	// Tags limits the search to queries against zones with any of the given tags.
+	Tags []string
+
+	// DateFrom and DateTo are dates in the format YYYY-MM-DD.
+	DateFrom string
+	DateTo   string
+	// TimeFrom and TimeTo are times of day in the format HH:MM or HH:MM:SS.
+	TimeFrom string
+	TimeTo   string
+}
+
+// QueryLog is a single DNS query logged by the server
+type QueryLog struct {
+	ID        int    `json:"id"`
+	Domain    string `json:"domain"`
+	SourceIP  string `json:"source_ip"`
+	Type      string `json:"type"`
+	Class     string `json:"class"`
+	Matched   bool   `json:"matched"`
+	Forwarded bool   `json:"forwarded"`
+	Blocked   bool   `json:"blocked"`
+	Data      string `json:"data"`
+	Date      string `json:"date"`
+}
+
+// UnmarshalJSON accepts both the API field names and the database column
+// names ("cls", "found") used by some server versions.
+func (q *QueryLog) UnmarshalJSON(data []byte) error {
+	type plain QueryLog
+	var aux struct {
+		plain
+		Cls   *string `json:"cls"`
+		Found *bool   `json:"found"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	*q = QueryLog(aux.plain)
+	if q.Class == "" && aux.Cls != nil {
+		q.Class = *aux.Cls
+	}
+	if aux.Found != nil {
+		q.Matched = *aux.Found
+	}
+	return nil
+}
+
+// SearchResult is a single page of query logs with its pagination metadata
+type SearchResult struct {
+	Results []QueryLog
+	Page    int
+	Pages   int
+	Total   int
+}
+
+// query encodes the options as URL query parameters
+func (o SearchOptions) query() url.Values {
+	q := url.Values{}
+	page := o.Page
+	if page < 1 {
+		page = 1
+	}
+	q.Set("page", strconv.Itoa(page))
+	if o.PerPage > 0 {
+		q.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+
+	for key, value := range map[string]string{
+		"domain":    o.Domain,
+		"source_ip": o.SourceIP,
+		"type":      o.Type,
+		"class":     o.Class,
+		"date_from": o.DateFrom,
+		"date_to":   o.DateTo,
+		"time_from": o.TimeFrom,
+		"time_to":   o.TimeTo,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+
+	for key, value := range map[string]*bool{
+		"matched":   o.Matched,
+		"forwarded": o.Forwarded,
+		"blocked":   o.Blocked,
+	} {
+		if value == nil {
+			continue
+		}
+		if *value {
+			q.Set(key, "1")
+		} else {
+			q.Set(key, "0")
+		}
+	}
+
+	if len(o.Tags) > 0 {
+		q.Set("tags", strings.Join(o.Tags, ","))
+	}
+	return q
+}
+
+// Search retrieves a single page of query logs matching opts
+func (c *Client) Search(ctx context.Context, opts SearchOptions) (*SearchResult, error) {
+	respBody, err := c.doRequest(ctx, "GET", "/search?"+opts.query().Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hit list and total are named differently across server versions
+	var page struct {
+		Results []QueryLog `json:"results"`
+		Data    []QueryLog `json:"data"`
+		Page    int        `json:"page"`
+		Pages   int        `json:"pages"`
+		Total   *int       `json:"total"`
+		Count   *int       `json:"count"`
+	}
+	if err := json.Unmarshal(respBody, &page); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	result := &SearchResult{Results: page.Results, Page: page.Page, Pages: page.Pages}
+	if result.Results == nil {
+		result.Results = page.Data
+	}
+	switch {
+	case page.Total != nil:
+		result.Total = *page.Total
+	case page.Count != nil:
+		result.Total = *page.Count
+	default:
+		result.Total = len(result.Results)
+	}
+	if result.Page < 1 {
+		result.Page = max(opts.Page, 1)
+	}
+
+	return result, nil
+}
+
+// SearchPages returns an iterator over the pages of query logs matching opts.
+// Iteration starts at opts.Page and stops at the last page, when the caller
+// stops ranging, or when ctx is cancelled. Errors are yielded once and end
+// the iteration.
+func (c *Client) SearchPages(ctx context.Context, opts SearchOptions) iter.Seq2[*SearchResult, error] {
+	return func(yield func(*SearchResult, error) bool) {
+		if opts.Page < 1 {
+			opts.Page = 1
+		}
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			result, err := c.Search(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(result, nil) {
+				return
+			}
+
+			if len(result.Results) == 0 || result.Page >= result.Pages {
+				return
+			}
+			opts.Page = result.Page + 1
+		}
+	}
+}
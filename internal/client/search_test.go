@@ -0,0 +1,114 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// TestSearchQuery tests that filters are encoded as query parameters
+func TestSearchQuery(t *testing.T) {
+	var query map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query = map[string]string{}
+		for key := range r.URL.Query() {
+			query[key] = r.URL.Query().Get(key)
+		}
+		w.Write([]byte(`{"results": [
+			{"id": 7, "domain": "canary.example.com", "source_ip": "10.0.0.5", "type": "A", "cls": "IN", "found": true, "forwarded": false, "blocked": false, "date": "2024-05-01 10:00:00"}
+		], "page": 1, "pages": 1, "count": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	matched, blocked := true, false
+	result, err := client.Search(context.Background(), SearchOptions{
+		Domain:   "canary.example.com",
+		SourceIP: "10.0.0.5",
+		Type:     "A",
+		Class:    "IN",
+		Matched:  &matched,
+		Blocked:  &blocked,
+		Tags:     []string{"canary", "prod"},
+		DateFrom: "2024-05-01",
+		TimeFrom: "09:00",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"page":      "1",
+		"domain":    "canary.example.com",
+		"source_ip": "10.0.0.5",
+		"type":      "A",
+		"class":     "IN",
+		"matched":   "1",
+		"blocked":   "0",
+		"tags":      "canary,prod",
+		"date_from": "2024-05-01",
+		"time_from": "09:00",
+	}
+	for key, value := range expected {
+		if query[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, query[key])
+		}
+	}
+	if _, ok := query["forwarded"]; ok {
+		t.Errorf("Expected unset filter forwarded to be omitted, got %v", query)
+	}
+
+	if result.Total != 1 || len(result.Results) != 1 {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+	hit := result.Results[0]
+	if hit.ID != 7 || hit.Class != "IN" || !hit.Matched || hit.SourceIP != "10.0.0.5" {
+		t.Errorf("Unexpected hit: %+v", hit)
+	}
+}
+
+// TestSearchPages tests that the page iterator follows pagination and can be stopped early
+func TestSearchPages(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		fmt.Fprintf(w, `{"data": [{"id": %d, "class": "IN", "matched": true}], "page": %d, "pages": 3, "total": 3}`, page, page)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+
+	var ids []int
+	for page, err := range client.SearchPages(context.Background(), SearchOptions{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if page.Total != 3 {
+			t.Errorf("Expected total 3, got %d", page.Total)
+		}
+		for _, hit := range page.Results {
+			ids = append(ids, hit.ID)
+		}
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" || requests != 3 {
+		t.Errorf("Expected hits [1 2 3] in 3 requests, got %v in %d", ids, requests)
+	}
+
+	requests = 0
+	for range client.SearchPages(context.Background(), SearchOptions{Page: 2}) {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request when stopping early, got %d", requests)
+	}
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultMaxConcurrency is the number of requests a new client allows in
+// flight at once. Terraform runs up to 10 operations in parallel, which is
+// more than a SQLite backed SnitchDNS handles without "database is locked"
+// errors.
+const DefaultMaxConcurrency = 4
+
+// throttle limits the requests a client sends. It caps the number of
+// requests in flight and, optionally, the request rate with a token bucket.
+//
+// The concurrency limit adapts to the server: it is halved when a response
+// shows that the server is overloaded and grows by one for every limit
+// other responses, up to the configured maximum (AIMD).
+type throttle struct {
+	mu          sync.Mutex
+	initialized bool
+
+	maxLimit float64
+	limit    float64
+	inFlight int
+	// epoch is incremented on every decrease. Failures of requests started
+	// in an earlier epoch do not decrease the limit again, so one burst of
+	// errors only halves it once.
+	epoch uint64
+	// changed is closed and replaced whenever a slot may have become free
+	changed chan struct{}
+
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// init reads the limits from the client on first use
+func (t *throttle) init(c *Client) {
+	if t.initialized {
+		return
+	}
+	t.initialized = true
+
+	t.maxLimit = float64(c.MaxConcurrency)
+	t.limit = t.maxLimit
+	t.changed = make(chan struct{})
+
+	if c.RequestsPerSecond > 0 {
+		t.rate = c.RequestsPerSecond
+		t.burst = math.Max(1, math.Floor(c.RequestsPerSecond))
+		t.tokens = t.burst
+		t.last = time.Now()
+	}
+}
+
+// acquire waits for a free slot and, if a rate is set, a token. It returns
+// the epoch the request was started in, which must be passed to release.
+func (t *throttle) acquire(ctx context.Context, c *Client) (uint64, error) {
+	epoch, err := t.acquireSlot(ctx, c)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := t.waitToken(ctx); err != nil {
+		t.release(epoch, 0, nil)
+		return 0, err
+	}
+
+	return epoch, nil
+}
+
+// acquireSlot waits until fewer than limit requests are in flight
+func (t *throttle) acquireSlot(ctx context.Context, c *Client) (uint64, error) {
+	for {
+		t.mu.Lock()
+		t.init(c)
+		if t.maxLimit <= 0 || t.inFlight < int(t.limit) {
+			t.inFlight++
+			epoch := t.epoch
+			t.mu.Unlock()
+			return epoch, nil
+		}
+		changed := t.changed
+		t.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// waitToken takes a token from the bucket, waiting until one is available
+func (t *throttle) waitToken(ctx context.Context) error {
+	t.mu.Lock()
+	if t.rate <= 0 {
+		t.mu.Unlock()
+		return nil
+	}
+
+	now := time.Now()
+	t.tokens = math.Min(t.burst, t.tokens+now.Sub(t.last).Seconds()*t.rate)
+	t.last = now
+	// Reserve the token up front; a negative balance queues the caller
+	// behind the others that are already waiting
+	t.tokens--
+	wait := time.Duration(-t.tokens / t.rate * float64(time.Second))
+	t.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, wait); err != nil {
+		t.mu.Lock()
+		t.tokens++
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// release frees the slot of a finished request. statusCode and body are the
+// status and body of the response; statusCode is 0 if none was received, and
+// such requests leave the limit as it is.
+func (t *throttle) release(epoch uint64, statusCode int, body []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+
+	if t.maxLimit > 0 && statusCode != 0 {
+		overloaded := isOverloaded(statusCode, body)
+		switch {
+		case overloaded && epoch == t.epoch:
+			t.limit = math.Max(1, math.Floor(t.limit/2))
+			t.epoch++
+		case !overloaded:
+			t.limit = math.Min(t.maxLimit, t.limit+1/t.limit)
+		}
+	}
+
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+// isOverloaded reports whether a response shows that the server is
+// overloaded. SnitchDNS answers validation and conflict errors with a 500
+// and an error envelope; those say nothing about the load, unlike a 500
+// without one, e.g. from a "database is locked" exception.
+func isOverloaded(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusInternalServerError:
+		return !newAPIError(statusCode, body).HasEnvelope()
+	}
+	return false
+}
+
+// currentLimit returns the current concurrency limit, for tests
+func (t *throttle) currentLimit() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestMaxConcurrency tests that no more than MaxConcurrency requests are in flight at once
+func TestMaxConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxConcurrency = 2
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetZone(context.Background(), "1"); err != nil {
+				t.Errorf("Expected request to succeed, got: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak.Load() != 2 {
+		t.Errorf("Expected at most 2 requests in flight, peak was %d", peak.Load())
+	}
+}
+
+// TestMaxConcurrencyContext tests that waiting for a slot stops when the context is done
+func TestMaxConcurrencyContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-key")
+	client.MaxConcurrency = 1
+
+	go client.GetZone(context.Background(), "1")
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetZone(ctx, "1"); err != context.DeadlineExceeded {
+		t.Errorf("Expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+// TestAdaptiveConcurrency tests that overload responses halve the limit once
+// per burst and that other responses restore it
+func TestAdaptiveConcurrency(t *testing.T) {
+	c := NewClient("http://unused", "test-key")
+	c.MaxConcurrency = 8
+	ctx := context.Background()
+
+	// A burst of concurrent failures only halves the limit once
+	var epochs []uint64
+	for range 4 {
+		epoch, err := c.throttle.acquire(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		epochs = append(epochs, epoch)
+	}
+	for _, epoch := range epochs {
+		c.throttle.release(epoch, http.StatusInternalServerError, []byte("Internal Server Error"))
+	}
+	if got := c.throttle.currentLimit(); got != 4 {
+		t.Errorf("Expected limit 4 after one burst of errors, got %v", got)
+	}
+
+	// Later failures halve it again, down to 1
+	for range 5 {
+		epoch, _ := c.throttle.acquire(ctx, c)
+		c.throttle.release(epoch, http.StatusServiceUnavailable, nil)
+	}
+	if got := c.throttle.currentLimit(); got != 1 {
+		t.Errorf("Expected limit 1, got %v", got)
+	}
+
+	// Transport errors leave the limit as it is
+	epoch, _ := c.throttle.acquire(ctx, c)
+	c.throttle.release(epoch, 0, nil)
+	if got := c.throttle.currentLimit(); got != 1 {
+		t.Errorf("Expected limit 1 after a transport error, got %v", got)
+	}
+
+	// Errors reported by SnitchDNS as a 500 with an envelope raise it like successes
+	for range 2 {
+		epoch, _ := c.throttle.acquire(ctx, c)
+		c.throttle.release(epoch, http.StatusInternalServerError, []byte(`{"success": false, "code": 5005, "message": "Invalid type"}`))
+	}
+	if got := c.throttle.currentLimit(); got != 2.5 {
+		t.Errorf("Expected limit 2.5 after validation errors, got %v", got)
+	}
+
+	// Successes recover the limit up to MaxConcurrency
+	for range 100 {
+		epoch, _ := c.throttle.acquire(ctx, c)
+		c.throttle.release(epoch, http.StatusOK, nil)
+	}
+	if got := c.throttle.currentLimit(); got != 8 {
+		t.Errorf("Expected limit to recover to 8, got %v", got)
+	}
+}
+
+// TestIsOverloaded tests which responses lower the concurrency limit
+func TestIsOverloaded(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		want       bool
+	}{
+		{http.StatusTooManyRequests, "", true},
+		{http.StatusBadGateway, "", true},
+		{http.StatusServiceUnavailable, "", true},
+		{http.StatusGatewayTimeout, "", true},
+		{http.StatusInternalServerError, "Internal Server Error", true},
+		{http.StatusInternalServerError, `{"success": false, "code": 5003, "message": "Domain already exists"}`, false},
+		{http.StatusNotImplemented, "", false},
+		{http.StatusBadRequest, "", false},
+		{http.StatusOK, "", false},
+	}
+
+	for _, tt := range tests {
+		if got := isOverloaded(tt.statusCode, []byte(tt.body)); got != tt.want {
+			t.Errorf("isOverloaded(%d, %q) = %t, want %t", tt.statusCode, tt.body, got, tt.want)
+		}
+	}
+}
+
+// TestRequestsPerSecond tests that the token bucket spaces out requests after the burst
+func TestRequestsPerSecond(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-key")
+	client.RequestsPerSecond = 20
+
+	// The first 20 requests use the burst, the next 5 take about 250ms
+	start := time.Now()
+	for range 25 {
+		if _, err := client.GetZone(context.Background(), "1"); err != nil {
+			t.Fatalf("Expected request to succeed, got: %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 200*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("Expected 25 requests at 20/s with a burst of 20 to take about 250ms, took %s", elapsed)
+	}
+	if requests.Load() != 25 {
+		t.Errorf("Expected 25 requests, got %d", requests.Load())
+	}
+}
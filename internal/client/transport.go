@@ -0,0 +1,75 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportOptions configures the TLS and proxy settings of the transport
+// built by NewTransport. The zero value yields the behaviour of
+// http.DefaultTransport.
+type TransportOptions struct {
+	// CACertPEM holds additional PEM encoded CA certificates that are
+	// trusted besides the system roots
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded certificate and
+	// key presented to servers that require mutual TLS. Both or neither
+	// must be set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables verification of the server certificate
+	InsecureSkipVerify bool
+	// ProxyURL is the proxy all requests are sent through. If it is nil,
+	// the proxy is taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	// environment variables.
+	ProxyURL *url.URL
+	// MinTLSVersion is the minimum TLS version, e.g. tls.VersionTLS13. 0
+	// means the Go default, TLS 1.2.
+	MinTLSVersion uint16
+}
+
+// NewTransport builds an HTTP transport from opts, based on
+// http.DefaultTransport.
+func NewTransport(opts TransportOptions) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+	if opts.MinTLSVersion != 0 {
+		tlsConfig.MinVersion = opts.MinTLSVersion
+	}
+
+	if len(opts.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(opts.CACertPEM) {
+			return nil, errors.New("no valid PEM encoded certificate found in the CA certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(opts.ClientCertPEM) > 0 || len(opts.ClientKeyPEM) > 0 {
+		if len(opts.ClientCertPEM) == 0 || len(opts.ClientKeyPEM) == 0 {
+			return nil, errors.New("a client certificate and a client key must be set together")
+		}
+		cert, err := tls.X509KeyPair(opts.ClientCertPEM, opts.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	if opts.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(opts.ProxyURL)
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testZoneJSON = `{"id": 1, "domain": "example.com"}`
+
+// newTLSTestClient returns a client for server using a transport built from opts
+func newTLSTestClient(t *testing.T, server *httptest.Server, opts TransportOptions) *Client {
+	t.Helper()
+
+	transport, err := NewTransport(opts)
+	if err != nil {
+		t.Fatalf("NewTransport failed: %v", err)
+	}
+
+	client := NewClient(server.URL, "test-key")
+	client.HTTPClient.Transport = transport
+	client.MaxRetries = 0
+	return client
+}
+
+// testCertPEM PEM encodes the certificate of a TLS test server
+func testCertPEM(server *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+// testClientCertificate generates a self-signed client certificate and key
+func testClientCertificate(t *testing.T) (certPEM, keyPEM []byte, cert *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, cert
+}
+
+// TestTransportCACert tests that a server signed by an extra CA is only trusted when the CA is configured
+func TestTransportCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(testZoneJSON))
+	}))
+	defer server.Close()
+
+	if _, err := newTLSTestClient(t, server, TransportOptions{}).GetZone(context.Background(), "1"); err == nil {
+		t.Error("Expected an unknown CA to be rejected")
+	}
+
+	client := newTLSTestClient(t, server, TransportOptions{CACertPEM: testCertPEM(server)})
+	if _, err := client.GetZone(context.Background(), "1"); err != nil {
+		t.Errorf("Expected the configured CA to be trusted, got: %v", err)
+	}
+}
+
+// TestTransportInsecureSkipVerify tests that verification can be disabled
+func TestTransportInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(testZoneJSON))
+	}))
+	defer server.Close()
+
+	client := newTLSTestClient(t, server, TransportOptions{InsecureSkipVerify: true})
+	if _, err := client.GetZone(context.Background(), "1"); err != nil {
+		t.Errorf("Expected request to succeed without verification, got: %v", err)
+	}
+}
+
+// TestTransportClientCert tests mutual TLS against a server that requires a client certificate
+func TestTransportClientCert(t *testing.T) {
+	certPEM, keyPEM, cert := testClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "terraform" {
+			t.Errorf("Expected the client certificate, got %v", r.TLS.PeerCertificates)
+		}
+		w.Write([]byte(testZoneJSON))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	if _, err := newTLSTestClient(t, server, TransportOptions{CACertPEM: testCertPEM(server)}).GetZone(context.Background(), "1"); err == nil {
+		t.Error("Expected the server to reject a client without certificate")
+	}
+
+	client := newTLSTestClient(t, server, TransportOptions{
+		CACertPEM:     testCertPEM(server),
+		ClientCertPEM: certPEM,
+		ClientKeyPEM:  keyPEM,
+	})
+	if _, err := client.GetZone(context.Background(), "1"); err != nil {
+		t.Errorf("Expected mutual TLS to succeed, got: %v", err)
+	}
+}
+
+// TestTransportMinTLSVersion tests that servers below the minimum version are rejected
+func TestTransportMinTLSVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(testZoneJSON))
+	}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	client := newTLSTestClient(t, server, TransportOptions{CACertPEM: testCertPEM(server)})
+	if _, err := client.GetZone(context.Background(), "1"); err != nil {
+		t.Errorf("Expected TLS 1.2 to be accepted by default, got: %v", err)
+	}
+
+	client = newTLSTestClient(t, server, TransportOptions{CACertPEM: testCertPEM(server), MinTLSVersion: tls.VersionTLS13})
+	if _, err := client.GetZone(context.Background(), "1"); err == nil {
+		t.Error("Expected a TLS 1.2 server to be rejected with a TLS 1.3 minimum")
+	}
+}
+
+// TestTransportProxy tests that requests are sent through the configured proxy
+func TestTransportProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "snitchdns.invalid" {
+			t.Errorf("Expected a proxied request for snitchdns.invalid, got %s", r.URL)
+		}
+		proxied.Add(1)
+		w.Write([]byte(testZoneJSON))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	transport, err := NewTransport(TransportOptions{ProxyURL: proxyURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient("http://snitchdns.invalid/api/v1", "test-key")
+	client.HTTPClient.Transport = transport
+	if _, err := client.GetZone(context.Background(), "1"); err != nil {
+		t.Fatalf("Expected request through the proxy to succeed, got: %v", err)
+	}
+	if proxied.Load() != 1 {
+		t.Errorf("Expected 1 proxied request, got %d", proxied.Load())
+	}
+}
+
+// TestNewTransportErrors tests that invalid certificate material is rejected
+func TestNewTransportErrors(t *testing.T) {
+	certPEM, keyPEM, _ := testClientCertificate(t)
+
+	tests := []struct {
+		name string
+		opts TransportOptions
+		want string
+	}{
+		{"CA not PEM", TransportOptions{CACertPEM: []byte("not a certificate")}, "no valid PEM"},
+		{"cert without key", TransportOptions{ClientCertPEM: certPEM}, "must be set together"},
+		{"key without cert", TransportOptions{ClientKeyPEM: keyPEM}, "must be set together"},
+		{"mismatched pair", TransportOptions{ClientCertPEM: certPEM, ClientKeyPEM: []byte("garbage")}, "failed to load client certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTransport(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got: %v", tt.want, err)
+			}
+		})
+	}
+}
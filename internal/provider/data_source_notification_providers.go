@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NotificationProvidersDataSource{}
+
+// NewNotificationProvidersDataSource creates a new Notification Providers data source.
+func NewNotificationProvidersDataSource() datasource.DataSource {
+	return &NotificationProvidersDataSource{}
+}
+
+// NotificationProvidersDataSource defines the data source implementation.
+type NotificationProvidersDataSource struct {
+	client *client.Client
+}
+
+// NotificationProvidersDataSourceModel describes the data source data model.
+type NotificationProvidersDataSourceModel struct {
+	ID        types.String                `tfsdk:"id"`
+	Providers []notificationProviderModel `tfsdk:"providers"`
+}
+
+// notificationProviderModel describes a single notification provider.
+type notificationProviderModel struct {
+	ID      types.Int64  `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata sets the data source type name.
+func (d *NotificationProvidersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_providers"
+}
+
+// Schema defines the data source schema.
+func (d *NotificationProvidersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the notification providers of the SnitchDNS server and whether each one is enabled globally. Use it in preconditions to avoid subscribing zones to disabled providers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier of the data source.",
+			},
+			"providers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Notification providers known to the server.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Internal ID of the provider type.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the provider, as used by `snitchdns_zone_notification.provider_name`.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the provider is enabled globally on the server.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *NotificationProvidersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *NotificationProvidersDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	tflog.Debug(ctx, "Reading notification providers")
+
+	providers, err := d.client.ListNotificationProviders(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading notification providers",
+			fmt.Sprintf("Could not list notification providers: %s", err),
+		)
+		return
+	}
+
+	data := NotificationProvidersDataSourceModel{
+		ID:        types.StringValue("notification_providers"),
+		Providers: make([]notificationProviderModel, 0, len(providers)),
+	}
+	for _, p := range providers {
+		data.Providers = append(data.Providers, notificationProviderModel{
+			ID:      types.Int64Value(int64(p.ID)),
+			Name:    types.StringValue(p.Name),
+			Enabled: types.BoolValue(p.Enabled),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"snitchdns-tf/internal/testcontainer"
+)
+
+// TestAccNotificationProvidersDataSource tests listing the notification providers
+func TestAccNotificationProvidersDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationProvidersDataSourceConfig(container),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.snitchdns_notification_providers.all", "providers.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.snitchdns_notification_providers.all", "providers.*", map[string]string{
+						"name": "email",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccNotificationProvidersDataSourceConfig generates HCL configuration for the data source
+func testAccNotificationProvidersDataSourceConfig(container *testcontainer.SnitchDNSContainer) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+data "snitchdns_notification_providers" "all" {}
+
+output "email_enabled" {
+  value = anytrue([for p in data.snitchdns_notification_providers.all.providers : p.enabled if p.name == "email"])
+}
+`, container.GetAPIEndpoint(), container.APIKey)
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"snitchdns-tf/internal/client"
+)
+
+const (
+	// defaultQueryLogLimit is the number of hits returned when limit is not set.
+	defaultQueryLogLimit = 100
+	// maxQueryLogPageSize caps the page size requested from the server.
+	maxQueryLogPageSize = 100
+)
+
+var (
+	dateRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	timeRegex = regexp.MustCompile(`^\d{2}:\d{2}(:\d{2})?$`)
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &QueryLogsDataSource{}
+
+// NewQueryLogsDataSource creates a new Query Logs data source.
+func NewQueryLogsDataSource() datasource.DataSource {
+	return &QueryLogsDataSource{}
+}
+
+// QueryLogsDataSource defines the data source implementation.
+type QueryLogsDataSource struct {
+	client *client.Client
+}
+
+// QueryLogsDataSourceModel describes the data source data model.
+type QueryLogsDataSourceModel struct {
+	ID        types.String    `tfsdk:"id"`
+	Domain    types.String    `tfsdk:"domain"`
+	SourceIP  types.String    `tfsdk:"source_ip"`
+	Type      types.String    `tfsdk:"type"`
+	Class     types.String    `tfsdk:"class"`
+	Matched   types.Bool      `tfsdk:"matched"`
+	Forwarded types.Bool      `tfsdk:"forwarded"`
+	Blocked   types.Bool      `tfsdk:"blocked"`
+	Tags      types.List      `tfsdk:"tags"`
+	DateFrom  types.String    `tfsdk:"date_from"`
+	DateTo    types.String    `tfsdk:"date_to"`
+	TimeFrom  types.String    `tfsdk:"time_from"`
+	TimeTo    types.String    `tfsdk:"time_to"`
+	Limit     types.Int64     `tfsdk:"limit"`
+	Total     types.Int64     `tfsdk:"total"`
+	Results   []queryLogModel `tfsdk:"results"`
+}
+
+// queryLogModel describes a single query log hit.
+type queryLogModel struct {
+	ID        types.Int64  `tfsdk:"id"`
+	Domain    types.String `tfsdk:"domain"`
+	SourceIP  types.String `tfsdk:"source_ip"`
+	Type      types.String `tfsdk:"type"`
+	Class     types.String `tfsdk:"class"`
+	Matched   types.Bool   `tfsdk:"matched"`
+	Forwarded types.Bool   `tfsdk:"forwarded"`
+	Blocked   types.Bool   `tfsdk:"blocked"`
+	Data      types.String `tfsdk:"data"`
+	Date      types.String `tfsdk:"date"`
+}
+
+// Metadata sets the data source type name.
+func (d *QueryLogsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_query_logs"
+}
+
+// Schema defines the data source schema.
+func (d *QueryLogsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	dateValidators := []validator.String{
+		stringvalidator.RegexMatches(dateRegex, "must be a date in the format YYYY-MM-DD"),
+	}
+	timeValidators := []validator.String{
+		stringvalidator.RegexMatches(timeRegex, "must be a time in the format HH:MM or HH:MM:SS"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Searches the DNS query log of the SnitchDNS server. Every filter is optional; unset filters match everything. Useful in `check` blocks to confirm that a canary zone has been queried.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier of the data source.",
+			},
+			"domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries for this domain.",
+			},
+			"source_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries sent from this IP address.",
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries of this record type, e.g. `A` or `TXT`.",
+			},
+			"class": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries of this class, e.g. `IN`.",
+			},
+			"matched": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries that did (`true`) or did not (`false`) match a zone.",
+			},
+			"forwarded": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries that were (`true`) or were not (`false`) forwarded.",
+			},
+			"blocked": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries that were (`true`) or were not (`false`) blocked by a restriction.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return queries against zones with any of these tags.",
+			},
+			"date_from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries on or after this date (`YYYY-MM-DD`).",
+				Validators:          dateValidators,
+			},
+			"date_to": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries on or before this date (`YYYY-MM-DD`).",
+				Validators:          dateValidators,
+			},
+			"time_from": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries at or after this time of day (`HH:MM` or `HH:MM:SS`).",
+				Validators:          timeValidators,
+			},
+			"time_to": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return queries at or before this time of day (`HH:MM` or `HH:MM:SS`).",
+				Validators:          timeValidators,
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of hits to return. Defaults to `%d`.", defaultQueryLogLimit),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total number of matching queries on the server, which may exceed `limit`.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Matching queries, newest first as returned by the server.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the log entry.",
+						},
+						"domain": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried domain.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "IP address the query came from.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried record type.",
+						},
+						"class": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Queried class.",
+						},
+						"matched": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query matched a zone.",
+						},
+						"forwarded": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was forwarded.",
+						},
+						"blocked": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the query was blocked by a restriction.",
+						},
+						"data": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Answer returned for the query.",
+						},
+						"date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the query was received.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *QueryLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *QueryLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data QueryLogsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := client.SearchOptions{
+		Domain:    data.Domain.ValueString(),
+		SourceIP:  data.SourceIP.ValueString(),
+		Type:      data.Type.ValueString(),
+		Class:     data.Class.ValueString(),
+		Matched:   data.Matched.ValueBoolPointer(),
+		Forwarded: data.Forwarded.ValueBoolPointer(),
+		Blocked:   data.Blocked.ValueBoolPointer(),
+		DateFrom:  data.DateFrom.ValueString(),
+		DateTo:    data.DateTo.ValueString(),
+		TimeFrom:  data.TimeFrom.ValueString(),
+		TimeTo:    data.TimeTo.ValueString(),
+	}
+	if !data.Tags.IsNull() {
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &opts.Tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	limit := int64(defaultQueryLogLimit)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+	opts.PerPage = int(min(limit, maxQueryLogPageSize))
+
+	tflog.Debug(ctx, "Searching query logs", map[string]any{
+		"domain": opts.Domain,
+		"limit":  limit,
+	})
+
+	data.Results = []queryLogModel{}
+	data.Total = types.Int64Value(0)
+	for page, err := range d.client.SearchPages(ctx, opts) {
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error searching query logs",
+				fmt.Sprintf("Could not search query logs: %s", err),
+			)
+			return
+		}
+
+		data.Total = types.Int64Value(int64(page.Total))
+		for _, hit := range page.Results {
+			if int64(len(data.Results)) >= limit {
+				break
+			}
+			data.Results = append(data.Results, queryLogModel{
+				ID:        types.Int64Value(int64(hit.ID)),
+				Domain:    types.StringValue(hit.Domain),
+				SourceIP:  types.StringValue(hit.SourceIP),
+				Type:      types.StringValue(hit.Type),
+				Class:     types.StringValue(hit.Class),
+				Matched:   types.BoolValue(hit.Matched),
+				Forwarded: types.BoolValue(hit.Forwarded),
+				Blocked:   types.BoolValue(hit.Blocked),
+				Data:      types.StringValue(hit.Data),
+				Date:      types.StringValue(hit.Date),
+			})
+		}
+		if int64(len(data.Results)) >= limit {
+			break
+		}
+	}
+
+	data.ID = types.StringValue("query_logs")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"snitchdns-tf/internal/testcontainer"
+)
+
+// TestAccQueryLogsDataSource tests that a DNS query against a canary zone shows up in the query log
+func TestAccQueryLogsDataSource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	domain := "canary.querylog.example.com"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccQueryLogsDataSourceConfig(container, domain, false),
+			},
+			{
+				PreConfig: func() {
+					testAccSendDNSQuery(t, container, domain)
+				},
+				Config: testAccQueryLogsDataSourceConfig(container, domain, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.snitchdns_query_logs.canary", "results.#", func(value string) error {
+						if count, _ := strconv.Atoi(value); count < 1 {
+							return fmt.Errorf("expected at least one query log hit, got %s", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr("data.snitchdns_query_logs.canary", "results.0.domain", domain),
+					resource.TestCheckResourceAttr("data.snitchdns_query_logs.canary", "results.0.matched", "true"),
+					resource.TestCheckResourceAttr("data.snitchdns_query_logs.none", "results.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// testAccSendDNSQuery resolves domain against the DNS server of the container
+func testAccSendDNSQuery(t *testing.T, container *testcontainer.SnitchDNSContainer, domain string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	host, err := container.Container.Host(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get container host: %v", err)
+	}
+	port, err := container.GetDNSPort(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get DNS port: %v", err)
+	}
+
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", net.JoinHostPort(host, port))
+		},
+	}
+	if _, err := resolver.LookupHost(ctx, domain); err != nil {
+		t.Logf("DNS lookup of %s failed: %v", domain, err)
+	}
+}
+
+// testAccQueryLogsDataSourceConfig generates HCL configuration for query log testing
+func testAccQueryLogsDataSourceConfig(container *testcontainer.SnitchDNSContainer, domain string, withDataSource bool) string {
+	config := fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "canary" {
+  domain     = %[3]q
+  active     = true
+  catch_all  = false
+  forwarding = false
+  regex      = false
+}
+
+resource "snitchdns_record" "canary" {
+  zone_id = snitchdns_zone.canary.id
+  type    = "A"
+  cls     = "IN"
+  ttl     = 300
+  active  = true
+
+  data = {
+    address = "192.0.2.1"
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain)
+
+	if withDataSource {
+		config += fmt.Sprintf(`
+data "snitchdns_query_logs" "canary" {
+  domain = %[1]q
+  type   = "A"
+  limit  = 10
+}
+
+data "snitchdns_query_logs" "none" {
+  domain  = %[1]q
+  blocked = true
+}
+`, domain)
+	}
+
+	return config
+}
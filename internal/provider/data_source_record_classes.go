@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RecordClassesDataSource{}
+
+// NewRecordClassesDataSource creates a new RecordClasses data source.
+func NewRecordClassesDataSource() datasource.DataSource {
+	return &RecordClassesDataSource{}
+}
+
+// RecordClassesDataSource defines the data source implementation.
+type RecordClassesDataSource struct {
+	client *client.Client
+}
+
+// RecordClassesDataSourceModel describes the data source data model.
+type RecordClassesDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Classes types.List   `tfsdk:"classes"`
+}
+
+// Metadata sets the data source type name.
+func (d *RecordClassesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_classes"
+}
+
+// Schema defines the data source schema.
+func (d *RecordClassesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the record classes supported by the SnitchDNS server, such as `IN`. These are the values accepted by `snitchdns_record.cls`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier of the data source.",
+			},
+			"classes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Record classes supported by the server.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *RecordClassesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *RecordClassesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	values, err := d.client.RecordClasses(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading record classes",
+			fmt.Sprintf("Could not read record classes: %s", err),
+		)
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := RecordClassesDataSourceModel{
+		ID:      types.StringValue("record_classes"),
+		Classes: list,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
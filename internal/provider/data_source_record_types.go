@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RecordTypesDataSource{}
+
+// NewRecordTypesDataSource creates a new RecordTypes data source.
+func NewRecordTypesDataSource() datasource.DataSource {
+	return &RecordTypesDataSource{}
+}
+
+// RecordTypesDataSource defines the data source implementation.
+type RecordTypesDataSource struct {
+	client *client.Client
+}
+
+// RecordTypesDataSourceModel describes the data source data model.
+type RecordTypesDataSourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Types types.List   `tfsdk:"types"`
+}
+
+// Metadata sets the data source type name.
+func (d *RecordTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_types"
+}
+
+// Schema defines the data source schema.
+func (d *RecordTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the record types supported by the SnitchDNS server, such as `A` or `TXT`. These are the values accepted by `snitchdns_record.type`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Placeholder identifier of the data source.",
+			},
+			"types": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Record types supported by the server.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *RecordTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *RecordTypesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	values, err := d.client.RecordTypes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading record types",
+			fmt.Sprintf("Could not read record types: %s", err),
+		)
+		return
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := RecordTypesDataSourceModel{
+		ID:    types.StringValue("record_types"),
+		Types: list,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// dynamicToJSON converts a dynamic attribute value into a value that
+// encoding/json marshals the same way Terraform's jsonencode would.
+func dynamicToJSON(value types.Dynamic) (interface{}, error) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() || value.IsUnderlyingValueUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	return attrToJSON(value.UnderlyingValue())
+}
+
+// attrToJSON converts an attr.Value recursively into plain Go values.
+func attrToJSON(value attr.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value is not known yet")
+	}
+
+	switch v := value.(type) {
+	case basetypes.StringValue:
+		return v.ValueString(), nil
+	case basetypes.BoolValue:
+		return v.ValueBool(), nil
+	case basetypes.NumberValue:
+		return json.Number(v.ValueBigFloat().Text('g', -1)), nil
+	case basetypes.Int64Value:
+		return v.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return v.ValueFloat64(), nil
+	case basetypes.DynamicValue:
+		return attrToJSON(v.UnderlyingValue())
+	case basetypes.ListValue:
+		return elementsToJSON(v.Elements())
+	case basetypes.SetValue:
+		return elementsToJSON(v.Elements())
+	case basetypes.TupleValue:
+		return elementsToJSON(v.Elements())
+	case basetypes.MapValue:
+		return attributesToJSON(v.Elements())
+	case basetypes.ObjectValue:
+		return attributesToJSON(v.Attributes())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+// elementsToJSON converts list, set and tuple elements.
+func elementsToJSON(elements []attr.Value) (interface{}, error) {
+	result := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		converted, err := attrToJSON(element)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted)
+	}
+	return result, nil
+}
+
+// attributesToJSON converts map elements and object attributes.
+func attributesToJSON(attributes map[string]attr.Value) (interface{}, error) {
+	result := make(map[string]interface{}, len(attributes))
+	for key, element := range attributes {
+		converted, err := attrToJSON(element)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = converted
+	}
+	return result, nil
+}
+
+// jsonToDynamic converts raw JSON into a dynamic value. Arrays become tuples
+// and objects become objects, mirroring how Terraform types HCL literals.
+func jsonToDynamic(raw json.RawMessage) (types.Dynamic, error) {
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return types.DynamicNull(), nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return types.DynamicNull(), err
+	}
+	if decoded == nil {
+		return types.DynamicNull(), nil
+	}
+
+	value, err := jsonToAttr(decoded)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return types.DynamicValue(value), nil
+}
+
+// jsonToAttr converts a decoded JSON value into an attr.Value.
+func jsonToAttr(value interface{}) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
+	case []interface{}:
+		elementTypes := make([]attr.Type, 0, len(v))
+		elements := make([]attr.Value, 0, len(v))
+		for _, element := range v {
+			converted, err := jsonToAttr(element)
+			if err != nil {
+				return nil, err
+			}
+			elementTypes = append(elementTypes, converted.Type(context.Background()))
+			elements = append(elements, converted)
+		}
+		tuple, diags := types.TupleValue(elementTypes, elements)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to build tuple: %v", diags)
+		}
+		return tuple, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		attrTypes := make(map[string]attr.Type, len(v))
+		attributes := make(map[string]attr.Value, len(v))
+		for _, key := range keys {
+			converted, err := jsonToAttr(v[key])
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[key] = converted.Type(context.Background())
+			attributes[key] = converted
+		}
+		object, diags := types.ObjectValue(attrTypes, attributes)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to build object: %v", diags)
+		}
+		return object, nil
+	}
+
+	return nil, fmt.Errorf("unsupported JSON value %T", value)
+}
+
+// jsonEqual reports whether two values encode to semantically equal JSON.
+func jsonEqual(a, b interface{}) bool {
+	normalize := func(v interface{}) (interface{}, bool) {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil, false
+		}
+		return decoded, true
+	}
+
+	na, okA := normalize(a)
+	nb, okB := normalize(b)
+	return okA && okB && reflect.DeepEqual(na, nb)
+}
+
+// jsonEmpty reports whether raw JSON is absent or an empty string, list or object.
+func jsonEmpty(raw json.RawMessage) bool {
+	switch string(bytes.TrimSpace(raw)) {
+	case "", "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
@@ -3,16 +3,23 @@ package provider
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"math"
 
 	"snitchdns-tf/internal/client"
 	"snitchdns-tf/internal/testcontainer"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -29,8 +36,43 @@ type SnitchDNSProvider struct {
 
 // SnitchDNSProviderModel describes the provider data model.
 type SnitchDNSProviderModel struct {
-	APIUrl string `tfsdk:"api_url"`
-	APIKey string `tfsdk:"api_key"`
+	APIUrl                types.String         `tfsdk:"api_url"`
+	APIKey                types.String         `tfsdk:"api_key"`
+	APIKeyFile            types.String         `tfsdk:"api_key_file"`
+	APIKeyCommand         types.String         `tfsdk:"api_key_command"`
+	APIKeyCommandTimeout  types.String         `tfsdk:"api_key_command_timeout"`
+	MaxConcurrentRequests types.Int64          `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Float64        `tfsdk:"requests_per_second"`
+	RequestTimeout        types.String         `tfsdk:"request_timeout"`
+	MaxRetries            types.Int64          `tfsdk:"max_retries"`
+	RetryWaitMin          types.String         `tfsdk:"retry_wait_min"`
+	RetryWaitMax          types.String         `tfsdk:"retry_wait_max"`
+	UserAgentSuffix       types.String         `tfsdk:"user_agent_suffix"`
+	DebugLogging          types.Bool           `tfsdk:"debug_logging"`
+	CACertPEM             types.String         `tfsdk:"ca_cert_pem"`
+	CACertFile            types.String         `tfsdk:"ca_cert_file"`
+	ClientCert            types.String         `tfsdk:"client_cert"`
+	ClientKey             types.String         `tfsdk:"client_key"`
+	InsecureSkipVerify    types.Bool           `tfsdk:"insecure_skip_verify"`
+	ProxyURL              types.String         `tfsdk:"proxy_url"`
+	TLSMinVersion         types.String         `tfsdk:"tls_min_version"`
+	Profile               types.String         `tfsdk:"profile"`
+	ConfigFile            types.String         `tfsdk:"config_file"`
+	DefaultTags           types.Set            `tfsdk:"default_tags"`
+	ZoneDefaults          *zoneDefaultsModel   `tfsdk:"zone_defaults"`
+	RecordDefaults        *recordDefaultsModel `tfsdk:"record_defaults"`
+}
+
+// ProviderData is passed to resources when the provider is configured.
+type ProviderData struct {
+	// Client is the SnitchDNS API client
+	Client *client.Client
+	// DefaultTags are added to the tags of every zone
+	DefaultTags []string
+	// ZoneDefaults and RecordDefaults are used for the settings a zone or
+	// record does not set
+	ZoneDefaults   ZoneDefaults
+	RecordDefaults RecordDefaults
 }
 
 // Metadata sets the provider type name and version.
@@ -45,14 +87,146 @@ func (p *SnitchDNSProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 		MarkdownDescription: "Provider for managing SnitchDNS resources. Configure with API endpoint and authentication key.",
 		Attributes: map[string]schema.Attribute{
 			"api_url": schema.StringAttribute{
-				MarkdownDescription: "SnitchDNS API URL. Can also be set via SNITCHDNS_API_URL environment variable.",
+				MarkdownDescription: "SnitchDNS API URL, including the `/api/v1` base path, e.g. `https://dns.example.com/api/v1`. If the URL has no path, `/api/v1` is appended with a warning. Can also be set via SNITCHDNS_API_URL environment variable.",
 				Optional:            true,
+				Validators: []validator.String{
+					urlWithScheme("http", "https"),
+				},
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "SnitchDNS API Key for authentication. Can also be set via SNITCHDNS_API_KEY environment variable.",
+				MarkdownDescription: "SnitchDNS API Key for authentication. Conflicts with `api_key_file` and `api_key_command`. Can also be set via SNITCHDNS_API_KEY environment variable.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key_file"), path.MatchRoot("api_key_command")),
+				},
+			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file containing the API key, e.g. one mounted by a secret agent. Surrounding whitespace is ignored. Conflicts with `api_key_command`. Can also be set via SNITCHDNS_API_KEY_FILE environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key_command")),
+				},
+			},
+			"api_key_command": schema.StringAttribute{
+				MarkdownDescription: "Command run in the system shell that prints the API key, e.g. a password manager CLI. Surrounding whitespace of the output is ignored. Can also be set via SNITCHDNS_API_KEY_COMMAND environment variable.",
+				Optional:            true,
+			},
+			"api_key_command_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Time `api_key_command` may take, as a duration such as `30s`. Defaults to `%s`. Can also be set via SNITCHDNS_API_KEY_COMMAND_TIMEOUT environment variable.", defaultAPIKeyCommandTimeout),
+				Optional:            true,
+				Validators: []validator.String{
+					durationAtLeast(minCommandTimeout),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of API requests in flight at once. The limit is lowered automatically while the server is overloaded, i.e. answers with 429, 502, 503 or 504 or with a 500 that carries no SnitchDNS error, and recovers with other responses. `0` disables the limit. Defaults to `%d`. Can also be set via SNITCHDNS_MAX_CONCURRENT_REQUESTS environment variable.", client.DefaultMaxConcurrency),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Maximum rate of API requests, allowing bursts of up to one second's worth of requests. `0` disables the limit, which is the default. Can also be set via SNITCHDNS_REQUESTS_PER_SECOND environment variable.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Timeout of a single API request attempt, as a duration such as `30s` or `2m`. Defaults to `%s`. Can also be set via SNITCHDNS_REQUEST_TIMEOUT environment variable.", client.DefaultTimeout),
+				Optional:            true,
+				Validators: []validator.String{
+					durationAtLeast(minRequestTimeout),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of times a failed API request is retried. `0` disables retries. Defaults to `%d`. Can also be set via SNITCHDNS_MAX_RETRIES environment variable.", client.DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxMaxRetries),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Backoff before the first retry, as a duration such as `500ms`. The backoff doubles with every retry. Defaults to `%s`. Can also be set via SNITCHDNS_RETRY_WAIT_MIN environment variable.", client.DefaultRetryWaitMin),
+				Optional:            true,
+				Validators: []validator.String{
+					durationAtLeast(minRetryWait),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Upper bound of the backoff between retries, including waits requested with `Retry-After`. Must not be less than `retry_wait_min`. Defaults to `%s`. Can also be set via SNITCHDNS_RETRY_WAIT_MAX environment variable.", client.DefaultRetryWaitMax),
+				Optional:            true,
+				Validators: []validator.String{
+					durationAtLeast(minRetryWait),
+				},
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Text appended to the `User-Agent` header of API requests, e.g. to identify a CI pipeline in server logs. Can also be set via SNITCHDNS_USER_AGENT_SUFFIX environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(userAgentSuffixRegex, "must be non-empty printable ASCII"),
+				},
+			},
+			"debug_logging": schema.BoolAttribute{
+				MarkdownDescription: "Trace every API request and response, including the first 2 KiB of each body, at debug level in the `snitchdns_http` log subsystem. Credentials are masked. Defaults to `false`. Can also be set via SNITCHDNS_DEBUG_LOGGING environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system roots, e.g. for an internal CA. Can also be set via SNITCHDNS_CA_CERT_PEM environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path of a file with PEM encoded CA certificates to trust in addition to the system roots. May be combined with `ca_cert_pem`. Can also be set via SNITCHDNS_CA_CERT_FILE environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS. Requires `client_key`. Can also be set via SNITCHDNS_CLIENT_CERT environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_cert`. Can also be set via SNITCHDNS_CLIENT_KEY environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable verification of the server certificate. Only use this for local testing; prefer `ca_cert_pem` or `ca_cert_file` for private CAs. Can also be set via SNITCHDNS_INSECURE_SKIP_VERIFY environment variable.",
+				Optional:            true,
 			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy API requests are sent through, with scheme `http`, `https` or `socks5`. Defaults to the proxy from the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables. Can also be set via SNITCHDNS_PROXY_URL environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					urlWithScheme(proxySchemes...),
+				},
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version, `1.2` or `1.3`. Defaults to `1.2`. Can also be set via SNITCHDNS_TLS_MIN_VERSION environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1.2", "1.3"),
+				},
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of a profile in the SnitchDNS configuration file to take settings from. Attributes set in the provider block override the profile. Can also be set via SNITCHDNS_PROFILE environment variable.",
+				Optional:            true,
+			},
+			"config_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the SnitchDNS configuration file with the profiles, in JSON (`.json`) or YAML format. Defaults to `config.json`, `config.yaml` or `config.yml` in `$XDG_CONFIG_HOME/snitchdns`, or `~/.config/snitchdns` if `XDG_CONFIG_HOME` is not set. Can also be set via SNITCHDNS_CONFIG_FILE environment variable.",
+				Optional:            true,
+			},
+			"default_tags": schema.SetAttribute{
+				ElementType:         types.StringType,
+				MarkdownDescription: "Tags added to every `snitchdns_zone`. A zone's own `tags` take precedence: a default tag of the form `key:value` or `key=value` is left out if the zone has a tag with the same key. The merged tags are exposed as `tags_all`.",
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(zoneTag()),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"zone_defaults":   zoneDefaultsBlock(),
+			"record_defaults": recordDefaultsBlock(),
 		},
 	}
 }
@@ -67,15 +241,48 @@ func (p *SnitchDNSProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Use environment variables as fallback
-	apiURL := data.APIUrl
-	if apiURL == "" {
-		apiURL = os.Getenv("SNITCHDNS_API_URL")
+	// Settings missing from the provider block are taken from the selected
+	// profile, then from environment variables
+	applyProfile(&resp.Diagnostics, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiURL := configString(data.APIUrl, envAPIURL)
+	commandTimeout := configDuration(&resp.Diagnostics, "api_key_command_timeout", data.APIKeyCommandTimeout, envAPIKeyCommandTimeout, defaultAPIKeyCommandTimeout, minCommandTimeout)
+	apiKey := resolveAPIKey(ctx, &resp.Diagnostics, data, commandTimeout)
+
+	maxConcurrency := configInt64(&resp.Diagnostics, "max_concurrent_requests", data.MaxConcurrentRequests, envMaxConcurrentRequests, client.DefaultMaxConcurrency, 0, math.MaxInt32)
+	requestsPerSecond := configFloat64(&resp.Diagnostics, "requests_per_second", data.RequestsPerSecond, envRequestsPerSecond, 0)
+	requestTimeout := configDuration(&resp.Diagnostics, "request_timeout", data.RequestTimeout, envRequestTimeout, client.DefaultTimeout, minRequestTimeout)
+	maxRetries := configInt64(&resp.Diagnostics, "max_retries", data.MaxRetries, envMaxRetries, client.DefaultMaxRetries, 0, maxMaxRetries)
+	retryWaitMin := configDuration(&resp.Diagnostics, "retry_wait_min", data.RetryWaitMin, envRetryWaitMin, client.DefaultRetryWaitMin, minRetryWait)
+	retryWaitMax := configDuration(&resp.Diagnostics, "retry_wait_max", data.RetryWaitMax, envRetryWaitMax, client.DefaultRetryWaitMax, minRetryWait)
+
+	userAgentSuffix := configString(data.UserAgentSuffix, envUserAgentSuffix)
+	if userAgentSuffix != "" && !userAgentSuffixRegex.MatchString(userAgentSuffix) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_agent_suffix"),
+			"Invalid User Agent Suffix",
+			fmt.Sprintf("user_agent_suffix must be printable ASCII, got %q.", userAgentSuffix),
+		)
 	}
 
-	apiKey := data.APIKey
-	if apiKey == "" {
-		apiKey = os.Getenv("SNITCHDNS_API_KEY")
+	debugLogging := configBool(&resp.Diagnostics, "debug_logging", data.DebugLogging, envDebugLogging)
+
+	transport := configTransport(&resp.Diagnostics, data)
+
+	var defaultTags []string
+	if !data.DefaultTags.IsNull() {
+		resp.Diagnostics.Append(data.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+	}
+
+	if retryWaitMax < retryWaitMin {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid Retry Wait",
+			fmt.Sprintf("retry_wait_max (%s) must not be less than retry_wait_min (%s).", retryWaitMax, retryWaitMin),
+		)
 	}
 
 	// Validate required configuration
@@ -84,19 +291,11 @@ func (p *SnitchDNSProvider) Configure(ctx context.Context, req provider.Configur
 			path.Root("api_url"),
 			"Missing API URL",
 			"The provider cannot create the SnitchDNS API client as there is a missing or empty value for the API URL. "+
-				"Set the api_url value in the provider configuration or use the SNITCHDNS_API_URL environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
-	}
-
-	if apiKey == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("api_key"),
-			"Missing API Key",
-			"The provider cannot create the SnitchDNS API client as there is a missing or empty value for the API key. "+
-				"Set the api_key value in the provider configuration or use the SNITCHDNS_API_KEY environment variable. "+
+				"Set the api_url value in the provider configuration or use the "+envAPIURL+" environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+	} else {
+		apiURL = normalizeAPIURL(ctx, &resp.Diagnostics, apiURL)
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -104,14 +303,38 @@ func (p *SnitchDNSProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	tflog.Debug(ctx, "Configuring SnitchDNS client", map[string]any{
-		"api_url": apiURL,
+		"api_url":                 apiURL,
+		"max_concurrent_requests": maxConcurrency,
+		"requests_per_second":     requestsPerSecond,
+		"request_timeout":         requestTimeout.String(),
+		"max_retries":             maxRetries,
+		"retry_wait_min":          retryWaitMin.String(),
+		"retry_wait_max":          retryWaitMax.String(),
+		"debug_logging":           debugLogging,
 	})
 
 	// Create API client
 	client := client.NewClient(apiURL, apiKey)
+	client.MaxConcurrency = int(maxConcurrency)
+	client.RequestsPerSecond = requestsPerSecond
+	client.HTTPClient.Timeout = requestTimeout
+	client.HTTPClient.Transport = transport
+	client.MaxRetries = int(maxRetries)
+	client.RetryWaitMin = retryWaitMin
+	client.RetryWaitMax = retryWaitMax
+	client.UserAgent = "terraform-provider-snitchdns/" + p.version
+	if userAgentSuffix != "" {
+		client.UserAgent += " " + userAgentSuffix
+	}
+	client.DebugLogging = debugLogging
 
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &ProviderData{
+		Client:         client,
+		DefaultTags:    defaultTags,
+		ZoneDefaults:   data.ZoneDefaults.resolve(),
+		RecordDefaults: data.RecordDefaults.resolve(),
+	}
 }
 
 // Resources returns the list of resources supported by this provider.
@@ -119,12 +342,20 @@ func (p *SnitchDNSProvider) Resources(_ context.Context) []func() resource.Resou
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewRecordResource,
+		NewZoneRestrictionResource,
+		NewZoneRestrictionsResource,
+		NewZoneNotificationResource,
 	}
 }
 
 // DataSources returns the list of data sources supported by this provider.
 func (p *SnitchDNSProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewNotificationProvidersDataSource,
+		NewQueryLogsDataSource,
+		NewRecordTypesDataSource,
+		NewRecordClassesDataSource,
+	}
 }
 
 // New creates a new instance of the SnitchDNS provider.
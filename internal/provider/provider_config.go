@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"snitchdns-tf/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Environment variables used when the matching provider attribute is not set.
+const (
+	envAPIURL                = "SNITCHDNS_API_URL"
+	envAPIKey                = "SNITCHDNS_API_KEY"
+	envAPIKeyFile            = "SNITCHDNS_API_KEY_FILE"
+	envAPIKeyCommand         = "SNITCHDNS_API_KEY_COMMAND"
+	envAPIKeyCommandTimeout  = "SNITCHDNS_API_KEY_COMMAND_TIMEOUT"
+	envMaxConcurrentRequests = "SNITCHDNS_MAX_CONCURRENT_REQUESTS"
+	envRequestsPerSecond     = "SNITCHDNS_REQUESTS_PER_SECOND"
+	envRequestTimeout        = "SNITCHDNS_REQUEST_TIMEOUT"
+	envMaxRetries            = "SNITCHDNS_MAX_RETRIES"
+	envRetryWaitMin          = "SNITCHDNS_RETRY_WAIT_MIN"
+	envRetryWaitMax          = "SNITCHDNS_RETRY_WAIT_MAX"
+	envUserAgentSuffix       = "SNITCHDNS_USER_AGENT_SUFFIX"
+	envDebugLogging          = "SNITCHDNS_DEBUG_LOGGING"
+	envCACertPEM             = "SNITCHDNS_CA_CERT_PEM"
+	envCACertFile            = "SNITCHDNS_CA_CERT_FILE"
+	envClientCert            = "SNITCHDNS_CLIENT_CERT"
+	envClientKey             = "SNITCHDNS_CLIENT_KEY"
+	envInsecureSkipVerify    = "SNITCHDNS_INSECURE_SKIP_VERIFY"
+	envProxyURL              = "SNITCHDNS_PROXY_URL"
+	envTLSMinVersion         = "SNITCHDNS_TLS_MIN_VERSION"
+	envProfile               = "SNITCHDNS_PROFILE"
+	envConfigFile            = "SNITCHDNS_CONFIG_FILE"
+)
+
+// Bounds of the client tuning attributes.
+const (
+	minRequestTimeout = time.Second
+	minRetryWait      = time.Millisecond
+	minCommandTimeout = 100 * time.Millisecond
+	maxMaxRetries     = 20
+)
+
+// apiBasePath is the path of the SnitchDNS API below the server root
+const apiBasePath = "/api/v1"
+
+// tlsVersions maps the accepted values of tls_min_version to TLS versions
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// proxySchemes are the schemes accepted for proxy_url
+var proxySchemes = []string{"http", "https", "socks5"}
+
+// userAgentSuffixRegex matches printable ASCII, which is all a User-Agent
+// header may safely contain
+var userAgentSuffixRegex = regexp.MustCompile(`^[\x20-\x7e]+$`)
+
+// configString returns the configured value, or the environment variable env
+// if the attribute is not set or empty.
+func configString(value types.String, env string) string {
+	if v := value.ValueString(); v != "" {
+		return v
+	}
+	return os.Getenv(env)
+}
+
+// configBool returns the configured value, or the environment variable env
+// if the attribute is not set. The environment variable is parsed with
+// strconv.ParseBool.
+func configBool(diags *diag.Diagnostics, attr string, value types.Bool, env string) bool {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool()
+	}
+
+	v := os.Getenv(env)
+	if v == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid "+env,
+			fmt.Sprintf("The %s environment variable must be true or false, got %q.", env, v),
+		)
+		return false
+	}
+	return b
+}
+
+// configInt64 returns the configured value, or the environment variable env
+// if the attribute is not set, or def if neither is. The environment
+// variable is checked against the same bounds as the attribute.
+func configInt64(diags *diag.Diagnostics, attr string, value types.Int64, env string, def, minimum, maximum int64) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+
+	v := os.Getenv(env)
+	if v == "" {
+		return def
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < minimum || n > maximum {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid "+env,
+			fmt.Sprintf("The %s environment variable must be an integer between %d and %d, got %q.", env, minimum, maximum, v),
+		)
+		return def
+	}
+	return n
+}
+
+// configFloat64 returns the configured value, or the environment variable
+// env if the attribute is not set, or def if neither is. The environment
+// variable must be a non-negative number, like the attribute.
+func configFloat64(diags *diag.Diagnostics, attr string, value types.Float64, env string, def float64) float64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueFloat64()
+	}
+
+	v := os.Getenv(env)
+	if v == "" {
+		return def
+	}
+
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || f < 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid "+env,
+			fmt.Sprintf("The %s environment variable must be a non-negative number, got %q.", env, v),
+		)
+		return def
+	}
+	return f
+}
+
+// configDuration returns the configured duration, or the environment
+// variable env if the attribute is not set, or def if neither is. The
+// attribute is validated by durationAtLeast during validation; the
+// environment variable is checked here against the same minimum.
+func configDuration(diags *diag.Diagnostics, attr string, value types.String, env string, def, minimum time.Duration) time.Duration {
+	v := configString(value, env)
+	if v == "" {
+		return def
+	}
+
+	d, err := parseDurationAtLeast(v, minimum)
+	if err != nil {
+		source := fmt.Sprintf("The %s environment variable", env)
+		if value.ValueString() != "" {
+			source = attr
+		}
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Invalid Duration",
+			fmt.Sprintf("%s must be a duration such as 30s or 1m30s of at least %s, got %q: %s", source, minimum, v, err),
+		)
+		return def
+	}
+	return d
+}
+
+// configTransport builds the HTTP transport from the TLS and proxy
+// attributes and their environment variables. It returns nil if the
+// configuration is invalid.
+func configTransport(diags *diag.Diagnostics, data SnitchDNSProviderModel) *http.Transport {
+	var opts client.TransportOptions
+	start := diags.ErrorsCount()
+
+	opts.CACertPEM = []byte(configString(data.CACertPEM, envCACertPEM))
+	if caFile := configString(data.CACertFile, envCACertFile); caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unreadable CA Certificate File",
+				fmt.Sprintf("Could not read the CA certificate file %q: %s", caFile, err),
+			)
+		}
+		opts.CACertPEM = append(append(opts.CACertPEM, '\n'), pem...)
+	}
+
+	opts.ClientCertPEM = []byte(configString(data.ClientCert, envClientCert))
+	opts.ClientKeyPEM = []byte(configString(data.ClientKey, envClientKey))
+	if len(opts.ClientCertPEM) > 0 && len(opts.ClientKeyPEM) == 0 {
+		diags.AddAttributeError(
+			path.Root("client_key"),
+			"Missing Client Key",
+			"client_cert is set, but client_key is not. Set both to authenticate with a client certificate.",
+		)
+	}
+	if len(opts.ClientKeyPEM) > 0 && len(opts.ClientCertPEM) == 0 {
+		diags.AddAttributeError(
+			path.Root("client_cert"),
+			"Missing Client Certificate",
+			"client_key is set, but client_cert is not. Set both to authenticate with a client certificate.",
+		)
+	}
+
+	opts.InsecureSkipVerify = configBool(diags, "insecure_skip_verify", data.InsecureSkipVerify, envInsecureSkipVerify)
+	if opts.InsecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the certificate of the SnitchDNS server is not verified. "+
+				"Anyone able to intercept the connection can read the API key and tamper with DNS changes. "+
+				"Configure ca_cert_pem or ca_cert_file for a private CA instead, and only use this option for local testing.",
+		)
+	}
+
+	if proxy := configString(data.ProxyURL, envProxyURL); proxy != "" {
+		u, err := parseURLWithScheme(proxy, proxySchemes...)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Proxy URL",
+				fmt.Sprintf("The proxy URL must be an absolute URL with scheme %s, got %q: %s", strings.Join(proxySchemes, ", "), proxy, err),
+			)
+		}
+		opts.ProxyURL = u
+	}
+
+	if version := configString(data.TLSMinVersion, envTLSMinVersion); version != "" {
+		v, ok := tlsVersions[version]
+		if !ok {
+			diags.AddAttributeError(
+				path.Root("tls_min_version"),
+				"Invalid TLS Version",
+				fmt.Sprintf("The minimum TLS version must be 1.2 or 1.3, got %q.", version),
+			)
+		}
+		opts.MinTLSVersion = v
+	}
+
+	if diags.ErrorsCount() > start {
+		return nil
+	}
+
+	transport, err := client.NewTransport(opts)
+	if err != nil {
+		diags.AddError(
+			"Invalid TLS Configuration",
+			fmt.Sprintf("The provider cannot create the SnitchDNS API client: %s. "+
+				"Check that ca_cert_pem, ca_cert_file, client_cert and client_key contain PEM encoded certificates and keys.", err),
+		)
+		return nil
+	}
+	return transport
+}
+
+// normalizeAPIURL validates the API URL and removes a trailing slash. A URL
+// without a path gets apiBasePath appended, with a warning, as the web UI
+// would otherwise answer every request with its login page. It returns an
+// empty string if the URL is invalid.
+func normalizeAPIURL(ctx context.Context, diags *diag.Diagnostics, raw string) string {
+	u, err := parseURLWithScheme(raw, "http", "https")
+	if err == nil && (u.RawQuery != "" || u.Fragment != "") {
+		err = errors.New("must not contain a query or fragment")
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("api_url"),
+			"Invalid API URL",
+			fmt.Sprintf("The SnitchDNS API URL must be an http or https URL such as https://dns.example.com%s, got %q: %s", apiBasePath, raw, err),
+		)
+		return ""
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	if u.Path == "" {
+		u.Path = apiBasePath
+		diags.AddAttributeWarning(
+			path.Root("api_url"),
+			"API URL Without Base Path",
+			fmt.Sprintf("The SnitchDNS API URL %q has no path, so %s was appended. The API is served below %s; "+
+				"without it, the web UI answers with its login page. Set api_url to %q to silence this warning.",
+				raw, apiBasePath, apiBasePath, u.String()),
+		)
+	} else if !strings.HasSuffix(u.Path, apiBasePath) {
+		// A reverse proxy may map the API to another path, so this is only
+		// logged. Requests that reach the web UI fail with a clear error.
+		tflog.Warn(ctx, "SnitchDNS API URL does not end in "+apiBasePath, map[string]any{"api_url": u.String()})
+	}
+
+	return u.String()
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"snitchdns-tf/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testConfigureProvider runs Configure with the given attribute values, all
+// others being null, and returns the configured client
+func testConfigureProvider(t *testing.T, attrs map[string]tftypes.Value) (*client.Client, *provider.ConfigureResponse) {
+	t.Helper()
+
+	p := &SnitchDNSProvider{version: "test"}
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(context.Background(), provider.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	req := provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(configType, attrs)},
+	}
+	resp := &provider.ConfigureResponse{}
+	p.Configure(context.Background(), req, resp)
+
+	c, _ := resp.DataSourceData.(*client.Client)
+	return c, resp
+}
+
+// testClearProviderEnv unsets every environment variable the provider reads
+func testClearProviderEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{
+		envAPIURL, envAPIKey, envAPIKeyFile, envAPIKeyCommand, envAPIKeyCommandTimeout, envMaxConcurrentRequests, envRequestsPerSecond, envRequestTimeout,
+		envMaxRetries, envRetryWaitMin, envRetryWaitMax, envUserAgentSuffix, envDebugLogging, envCACertPEM, envCACertFile,
+		envClientCert, envClientKey, envInsecureSkipVerify, envProxyURL, envTLSMinVersion, envProfile, envConfigFile,
+	} {
+		t.Setenv(env, "")
+	}
+}
+
+// TestProviderConfigureDefaults tests the client settings when only the URL and key are set
+func TestProviderConfigureDefaults(t *testing.T) {
+	testClearProviderEnv(t)
+	t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+	t.Setenv(envAPIKey, "test-key")
+
+	c, resp := testConfigureProvider(t, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if c.HTTPClient.Timeout != client.DefaultTimeout || c.MaxRetries != client.DefaultMaxRetries ||
+		c.RetryWaitMin != client.DefaultRetryWaitMin || c.RetryWaitMax != client.DefaultRetryWaitMax {
+		t.Errorf("Expected default retry settings, got timeout=%s retries=%d wait=%s-%s",
+			c.HTTPClient.Timeout, c.MaxRetries, c.RetryWaitMin, c.RetryWaitMax)
+	}
+	if c.MaxConcurrency != client.DefaultMaxConcurrency || c.RequestsPerSecond != 0 {
+		t.Errorf("Expected default throttling, got concurrency=%d rate=%v", c.MaxConcurrency, c.RequestsPerSecond)
+	}
+	if c.UserAgent != "terraform-provider-snitchdns/test" {
+		t.Errorf("Unexpected user agent %q", c.UserAgent)
+	}
+	if c.DebugLogging {
+		t.Error("Expected HTTP tracing to be disabled by default")
+	}
+}
+
+// TestProviderConfigureDefaultTags tests that default_tags are passed to resources
+func TestProviderConfigureDefaultTags(t *testing.T) {
+	testClearProviderEnv(t)
+	t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+	t.Setenv(envAPIKey, "test-key")
+
+	_, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"default_tags": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "team:dns"),
+			tftypes.NewValue(tftypes.String, "managed-by:terraform"),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	data, ok := resp.ResourceData.(*ProviderData)
+	if !ok {
+		t.Fatalf("Expected *ProviderData, got %T", resp.ResourceData)
+	}
+	if data.Client == nil || resp.DataSourceData != data.Client {
+		t.Error("Expected resources and data sources to share the client")
+	}
+	if got := slices.Sorted(slices.Values(data.DefaultTags)); !slices.Equal(got, []string{"managed-by:terraform", "team:dns"}) {
+		t.Errorf("Expected default tags [managed-by:terraform team:dns], got %v", got)
+	}
+}
+
+// TestProviderConfigureTuning tests that attributes take precedence over environment variables
+func TestProviderConfigureTuning(t *testing.T) {
+	testClearProviderEnv(t)
+	t.Setenv(envRequestTimeout, "5m")
+	t.Setenv(envMaxRetries, "7")
+	t.Setenv(envRetryWaitMax, "2m")
+	t.Setenv(envUserAgentSuffix, "env-suffix")
+	t.Setenv(envRequestsPerSecond, "2.5")
+	t.Setenv(envDebugLogging, "true")
+
+	c, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"api_url":           tftypes.NewValue(tftypes.String, "http://localhost:8000/api/v1"),
+		"api_key":           tftypes.NewValue(tftypes.String, "test-key"),
+		"request_timeout":   tftypes.NewValue(tftypes.String, "90s"),
+		"max_retries":       tftypes.NewValue(tftypes.Number, 0),
+		"retry_wait_min":    tftypes.NewValue(tftypes.String, "250ms"),
+		"user_agent_suffix": tftypes.NewValue(tftypes.String, "ci/1234"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if c.HTTPClient.Timeout != 90*time.Second {
+		t.Errorf("Expected request_timeout from the attribute, got %s", c.HTTPClient.Timeout)
+	}
+	if c.MaxRetries != 0 {
+		t.Errorf("Expected max_retries 0 from the attribute, got %d", c.MaxRetries)
+	}
+	if c.RetryWaitMin != 250*time.Millisecond || c.RetryWaitMax != 2*time.Minute {
+		t.Errorf("Expected backoff 250ms-2m, got %s-%s", c.RetryWaitMin, c.RetryWaitMax)
+	}
+	if c.RequestsPerSecond != 2.5 {
+		t.Errorf("Expected requests_per_second from the environment, got %v", c.RequestsPerSecond)
+	}
+	if c.UserAgent != "terraform-provider-snitchdns/test ci/1234" {
+		t.Errorf("Unexpected user agent %q", c.UserAgent)
+	}
+	if !c.DebugLogging {
+		t.Error("Expected debug_logging from the environment")
+	}
+}
+
+// TestProviderConfigureInvalidTuning tests that invalid environment variables and
+// inconsistent backoff bounds are reported
+func TestProviderConfigureInvalidTuning(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   map[string]string
+		attrs map[string]tftypes.Value
+	}{
+		{"timeout", map[string]string{envRequestTimeout: "soon"}, nil},
+		{"timeout too short", map[string]string{envRequestTimeout: "10ms"}, nil},
+		{"retries", map[string]string{envMaxRetries: "-1"}, nil},
+		{"too many retries", map[string]string{envMaxRetries: "100"}, nil},
+		{"concurrency", map[string]string{envMaxConcurrentRequests: "many"}, nil},
+		{"rate", map[string]string{envRequestsPerSecond: "-2"}, nil},
+		{"user agent", map[string]string{envUserAgentSuffix: "ci\n"}, nil},
+		{"wait bounds", nil, map[string]tftypes.Value{
+			"retry_wait_min": tftypes.NewValue(tftypes.String, "10s"),
+			"retry_wait_max": tftypes.NewValue(tftypes.String, "5s"),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClearProviderEnv(t)
+			t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+			t.Setenv(envAPIKey, "test-key")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, resp := testConfigureProvider(t, tt.attrs)
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Errorf("Expected 1 error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestProviderConfigureTLS tests that a CA file makes a server signed by it trusted
+func TestProviderConfigureTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"id": 1, "domain": "example.com"}`))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testClearProviderEnv(t)
+	t.Setenv(envCACertFile, caFile)
+
+	c, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"api_url":         tftypes.NewValue(tftypes.String, server.URL),
+		"api_key":         tftypes.NewValue(tftypes.String, "test-key"),
+		"tls_min_version": tftypes.NewValue(tftypes.String, "1.2"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if _, err := c.GetZone(context.Background(), "1"); err != nil {
+		t.Errorf("Expected the CA from ca_cert_file to be trusted, got: %v", err)
+	}
+}
+
+// TestProviderConfigureInsecureSkipVerify tests that disabling verification warns
+func TestProviderConfigureInsecureSkipVerify(t *testing.T) {
+	testClearProviderEnv(t)
+
+	_, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"api_url":              tftypes.NewValue(tftypes.String, "https://localhost:8443/api/v1"),
+		"api_key":              tftypes.NewValue(tftypes.String, "test-key"),
+		"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+	})
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Errorf("Expected a single warning, got: %v", resp.Diagnostics)
+	}
+}
+
+// TestProviderConfigureInvalidTLS tests that inconsistent TLS and proxy settings are reported
+func TestProviderConfigureInvalidTLS(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"client cert without key", map[string]string{envClientCert: "cert"}},
+		{"client key without cert", map[string]string{envClientKey: "key"}},
+		{"client cert not PEM", map[string]string{envClientCert: "cert", envClientKey: "key"}},
+		{"CA not PEM", map[string]string{envCACertPEM: "not a certificate"}},
+		{"missing CA file", map[string]string{envCACertFile: "/nonexistent/ca.pem"}},
+		{"insecure", map[string]string{envInsecureSkipVerify: "maybe"}},
+		{"proxy scheme", map[string]string{envProxyURL: "ftp://proxy:21"}},
+		{"proxy host", map[string]string{envProxyURL: "http://"}},
+		{"TLS version", map[string]string{envTLSMinVersion: "1.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClearProviderEnv(t)
+			t.Setenv(envAPIURL, "https://localhost:8443/api/v1")
+			t.Setenv(envAPIKey, "test-key")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, resp := testConfigureProvider(t, nil)
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Errorf("Expected 1 error, got: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestNormalizeAPIURL tests validation of the API URL and completion of the base path
+func TestNormalizeAPIURL(t *testing.T) {
+	tests := []struct {
+		raw         string
+		want        string
+		wantWarning bool
+		wantError   bool
+	}{
+		{"http://localhost:8000/api/v1", "http://localhost:8000/api/v1", false, false},
+		{"https://dns.example.com/api/v1/", "https://dns.example.com/api/v1", false, false},
+		{"https://example.com/snitchdns/api/v1", "https://example.com/snitchdns/api/v1", false, false},
+		{"http://localhost:8000", "http://localhost:8000/api/v1", true, false},
+		{"http://localhost:8000/", "http://localhost:8000/api/v1", true, false},
+		{"localhost:8000", "", false, true},
+		{"ftp://localhost/api/v1", "", false, true},
+		{"http:///api/v1", "", false, true},
+		{"http://localhost/api/v1?key=1", "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := normalizeAPIURL(context.Background(), &diags, tt.raw)
+
+			if got != tt.want {
+				t.Errorf("normalizeAPIURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+			if (diags.WarningsCount() > 0) != tt.wantWarning || diags.HasError() != tt.wantError {
+				t.Errorf("Expected warning=%t error=%t, got: %v", tt.wantWarning, tt.wantError, diags)
+			}
+		})
+	}
+}
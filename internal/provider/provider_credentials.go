@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultAPIKeyCommandTimeout bounds api_key_command unless
+// api_key_command_timeout is set
+const defaultAPIKeyCommandTimeout = 10 * time.Second
+
+// maxCommandStderrLength is the number of bytes of the standard error of
+// api_key_command that is shown in diagnostics
+const maxCommandStderrLength = 512
+
+// apiKeySource is one place the API key can be read from
+type apiKeySource struct {
+	// attr is the provider attribute the source belongs to, used as the
+	// path of diagnostics
+	attr string
+	// name names the source without its value, e.g. `api_key_file`
+	name string
+	// description names the source in logs and error diagnostics, e.g.
+	// `api_key_file "/run/secrets/snitchdns"`. It never contains the key.
+	description string
+	// load returns the API key
+	load func(ctx context.Context) (string, error)
+}
+
+// apiKeySources returns every configured source of the API key in
+// precedence order: the api_key, api_key_file and api_key_command
+// attributes, which are mutually exclusive, followed by the
+// SNITCHDNS_API_KEY, SNITCHDNS_API_KEY_FILE and SNITCHDNS_API_KEY_COMMAND
+// environment variables.
+func apiKeySources(data SnitchDNSProviderModel, commandTimeout time.Duration) []apiKeySource {
+	var sources []apiKeySource
+
+	add := func(attr, name, description, value string, load func(ctx context.Context) (string, error)) {
+		if value != "" {
+			sources = append(sources, apiKeySource{attr: attr, name: name, description: description, load: load})
+		}
+	}
+	literal := func(value string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) { return value, nil }
+	}
+	file := func(name string) func(context.Context) (string, error) {
+		return func(context.Context) (string, error) { return readAPIKeyFile(name) }
+	}
+	command := func(cmd string) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) { return runAPIKeyCommand(ctx, cmd, commandTimeout) }
+	}
+
+	apiKey := data.APIKey.ValueString()
+	apiKeyFile := data.APIKeyFile.ValueString()
+	apiKeyCommand := data.APIKeyCommand.ValueString()
+	add("api_key", "api_key", "api_key", apiKey, literal(apiKey))
+	add("api_key_file", "api_key_file", fmt.Sprintf("api_key_file %q", apiKeyFile), apiKeyFile, file(apiKeyFile))
+	add("api_key_command", "api_key_command", fmt.Sprintf("api_key_command %q", apiKeyCommand), apiKeyCommand, command(apiKeyCommand))
+
+	envKey := os.Getenv(envAPIKey)
+	envKeyFile := os.Getenv(envAPIKeyFile)
+	envKeyCommand := os.Getenv(envAPIKeyCommand)
+	add("api_key", envAPIKey+" environment variable", envAPIKey+" environment variable", envKey, literal(envKey))
+	add("api_key_file", envAPIKeyFile+" environment variable", fmt.Sprintf("%s environment variable %q", envAPIKeyFile, envKeyFile), envKeyFile, file(envKeyFile))
+	add("api_key_command", envAPIKeyCommand+" environment variable", fmt.Sprintf("%s environment variable %q", envAPIKeyCommand, envKeyCommand), envKeyCommand, command(envKeyCommand))
+
+	return sources
+}
+
+// resolveAPIKey loads the API key from the first configured source. Later
+// sources are not tried if it fails, so that a broken secret file or command
+// is reported instead of silently falling back to another key. It returns
+// an empty key if no source is configured or the source failed.
+func resolveAPIKey(ctx context.Context, diags *diag.Diagnostics, data SnitchDNSProviderModel, commandTimeout time.Duration) string {
+	sources := apiKeySources(data, commandTimeout)
+	if len(sources) == 0 {
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"Missing API Key",
+			"The provider cannot create the SnitchDNS API client as there is a missing or empty value for the API key. "+
+				"Set api_key, api_key_file or api_key_command in the provider configuration, or use the "+
+				envAPIKey+", "+envAPIKeyFile+" or "+envAPIKeyCommand+" environment variable. "+
+				"If one is already set, ensure the value is not empty.",
+		)
+		return ""
+	}
+
+	source := sources[0]
+	key, err := source.load(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(source.attr),
+			"Unable to Read API Key",
+			fmt.Sprintf("The provider could not read the API key from %s: %s", source.description, err),
+		)
+		return ""
+	}
+
+	fields := map[string]any{"api_key_source": source.description}
+	if len(sources) > 1 {
+		var ignored, ignoredNames []string
+		for _, s := range sources[1:] {
+			ignored = append(ignored, s.description)
+			ignoredNames = append(ignoredNames, s.name)
+		}
+		fields["ignored_api_key_sources"] = ignored
+
+		// Make a shadowed key visible without TF_LOG, as using another key
+		// than intended is hard to tell from the API errors alone. Only the
+		// names are shown, as a path or command may be sensitive too. A
+		// single source is only logged, as warning about it on every run
+		// would be noise.
+		diags.AddAttributeWarning(
+			path.Root(source.attr),
+			"Multiple API Key Sources",
+			fmt.Sprintf("The API key is read from %s. The other configured sources are ignored: %s.", source.name, strings.Join(ignoredNames, ", ")),
+		)
+	}
+	tflog.Info(ctx, "Using SnitchDNS API key from "+source.description, fields)
+
+	return key
+}
+
+// readAPIKeyFile reads an API key from a file, ignoring surrounding
+// whitespace such as a trailing newline
+func readAPIKeyFile(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", errors.New("the file is empty")
+	}
+	return key, nil
+}
+
+// runAPIKeyCommand runs command in the system shell and returns its standard
+// output without surrounding whitespace. The command is killed after
+// timeout.
+func runAPIKeyCommand(ctx context.Context, command string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Do not wait for children of the shell that keep stdout open after
+	// the shell itself was killed
+	cmd.WaitDelay = time.Second
+
+	stdout, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("the command did not finish within %s", timeout)
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > maxCommandStderrLength {
+			msg = msg[:maxCommandStderrLength] + "..."
+		}
+		if msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+
+	key := strings.TrimSpace(string(stdout))
+	if key == "" {
+		return "", errors.New("the command printed nothing")
+	}
+	return key, nil
+}
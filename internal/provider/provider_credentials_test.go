@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testWriteKeyFile writes an API key file and returns its path
+func testWriteKeyFile(t *testing.T, content string) string {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+// TestProviderConfigureAPIKeySources tests where the API key is read from and in which order
+func TestProviderConfigureAPIKeySources(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("api_key_command tests use a POSIX shell")
+	}
+
+	keyFile := testWriteKeyFile(t, "  file-key\n")
+
+	tests := []struct {
+		name        string
+		attrs       map[string]string
+		env         map[string]string
+		want        string
+		wantIgnored []string
+	}{
+		{"attribute", map[string]string{"api_key": "attr-key"}, map[string]string{envAPIKey: "env-key"}, "attr-key", []string{envAPIKey}},
+		{"file attribute", map[string]string{"api_key_file": keyFile}, map[string]string{envAPIKey: "env-key"}, "file-key", []string{envAPIKey}},
+		{"command attribute", map[string]string{"api_key_command": "printf ' cmd-key\\n'"}, map[string]string{envAPIKey: "env-key"}, "cmd-key", []string{envAPIKey}},
+		{"environment", nil, map[string]string{envAPIKey: "env-key", envAPIKeyFile: keyFile}, "env-key", []string{envAPIKeyFile}},
+		{"file environment", nil, map[string]string{envAPIKeyFile: keyFile, envAPIKeyCommand: "echo cmd-key"}, "file-key", []string{envAPIKeyCommand}},
+		{"command environment", nil, map[string]string{envAPIKeyCommand: "echo cmd-key"}, "cmd-key", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClearProviderEnv(t)
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			attrs := map[string]tftypes.Value{
+				"api_url": tftypes.NewValue(tftypes.String, "http://localhost:8000/api/v1"),
+			}
+			for k, v := range tt.attrs {
+				attrs[k] = tftypes.NewValue(tftypes.String, v)
+			}
+
+			c, resp := testConfigureProvider(t, attrs)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if c.APIKey != tt.want {
+				t.Errorf("Expected API key %q, got %q", tt.want, c.APIKey)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if len(tt.wantIgnored) == 0 {
+				if len(warnings) != 0 {
+					t.Errorf("Expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || warnings[0].Summary() != "Multiple API Key Sources" {
+				t.Fatalf("Expected a Multiple API Key Sources warning, got %v", warnings)
+			}
+			detail := warnings[0].Detail()
+			for _, ignored := range tt.wantIgnored {
+				if !strings.Contains(detail, ignored) {
+					t.Errorf("Expected the warning to name the ignored %s, got %q", ignored, detail)
+				}
+			}
+			for _, key := range []string{"attr-key", "env-key", "file-key", "cmd-key"} {
+				if strings.Contains(detail, key) {
+					t.Errorf("Expected the warning not to contain the key %q, got %q", key, detail)
+				}
+			}
+		})
+	}
+}
+
+// TestProviderConfigureAPIKeyErrors tests that a failing source is reported
+// without falling back to later sources and without revealing the key
+func TestProviderConfigureAPIKeyErrors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("api_key_command tests use a POSIX shell")
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"none", nil, "Missing API Key"},
+		{"missing file", map[string]string{envAPIKeyFile: "/nonexistent/api-key"}, "/nonexistent/api-key"},
+		{"empty file", map[string]string{envAPIKeyFile: testWriteKeyFile(t, "\n")}, "the file is empty"},
+		{"failing command", map[string]string{envAPIKeyCommand: "echo locked >&2; exit 3"}, "exit status 3: locked"},
+		{"silent command", map[string]string{envAPIKeyCommand: "true"}, "the command printed nothing"},
+		{"slow command", map[string]string{envAPIKeyCommand: "sleep 5; echo cmd-key", envAPIKeyCommandTimeout: "200ms"}, "did not finish within 200ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClearProviderEnv(t)
+			t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, resp := testConfigureProvider(t, nil)
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("Expected 1 error, got: %v", resp.Diagnostics)
+			}
+			d := resp.Diagnostics.Errors()[0]
+			if got := d.Summary() + ": " + d.Detail(); !strings.Contains(got, tt.want) {
+				t.Errorf("Expected error containing %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
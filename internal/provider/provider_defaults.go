@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ZoneDefaults are the values of the settings of a snitchdns_zone that are
+// not set in its configuration.
+type ZoneDefaults struct {
+	Active     bool
+	CatchAll   bool
+	Forwarding bool
+	Regex      bool
+}
+
+// RecordDefaults are the values of the settings of a snitchdns_record that
+// are not set in its configuration.
+type RecordDefaults struct {
+	Active bool
+	Class  string
+	TTL    int64
+}
+
+// defaultZoneDefaults are used unless overridden in the zone_defaults block
+var defaultZoneDefaults = ZoneDefaults{
+	Active:     true,
+	CatchAll:   false,
+	Forwarding: false,
+	Regex:      false,
+}
+
+// defaultRecordDefaults are used unless overridden in the record_defaults
+// block
+var defaultRecordDefaults = RecordDefaults{
+	Active: true,
+	Class:  "IN",
+	TTL:    3600,
+}
+
+// zoneDefaultsModel describes the zone_defaults block
+type zoneDefaultsModel struct {
+	Active     types.Bool `tfsdk:"active"`
+	CatchAll   types.Bool `tfsdk:"catch_all"`
+	Forwarding types.Bool `tfsdk:"forwarding"`
+	Regex      types.Bool `tfsdk:"regex"`
+}
+
+// recordDefaultsModel describes the record_defaults block
+type recordDefaultsModel struct {
+	Active types.Bool   `tfsdk:"active"`
+	Class  types.String `tfsdk:"cls"`
+	TTL    types.Int64  `tfsdk:"ttl"`
+}
+
+// zoneDefaultsBlock returns the schema of the zone_defaults block
+func zoneDefaultsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Values of the `snitchdns_zone` settings that a zone does not set itself.",
+		Attributes: map[string]schema.Attribute{
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Default of `active`. Defaults to `true`.",
+				Optional:            true,
+			},
+			"catch_all": schema.BoolAttribute{
+				MarkdownDescription: "Default of `catch_all`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"forwarding": schema.BoolAttribute{
+				MarkdownDescription: "Default of `forwarding`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"regex": schema.BoolAttribute{
+				MarkdownDescription: "Default of `regex`. Defaults to `false`.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// recordDefaultsBlock returns the schema of the record_defaults block
+func recordDefaultsBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Values of the `snitchdns_record` settings that a record does not set itself.",
+		Attributes: map[string]schema.Attribute{
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Default of `active`. Defaults to `true`.",
+				Optional:            true,
+			},
+			"cls": schema.StringAttribute{
+				MarkdownDescription: "Default of `cls`. Defaults to `IN`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(recordMnemonicRegex, "must be an upper-case DNS class such as IN"),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Default of `ttl` in seconds. Defaults to `3600`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, math.MaxInt32),
+				},
+			},
+		},
+	}
+}
+
+// resolve returns the built-in zone defaults overridden by the values set in
+// the block. A nil block, i.e. one that is not configured, changes nothing.
+func (m *zoneDefaultsModel) resolve() ZoneDefaults {
+	defaults := defaultZoneDefaults
+	if m == nil {
+		return defaults
+	}
+	setBool(&defaults.Active, m.Active)
+	setBool(&defaults.CatchAll, m.CatchAll)
+	setBool(&defaults.Forwarding, m.Forwarding)
+	setBool(&defaults.Regex, m.Regex)
+	return defaults
+}
+
+// resolve returns the built-in record defaults overridden by the values set
+// in the block. A nil block, i.e. one that is not configured, changes
+// nothing.
+func (m *recordDefaultsModel) resolve() RecordDefaults {
+	defaults := defaultRecordDefaults
+	if m == nil {
+		return defaults
+	}
+	setBool(&defaults.Active, m.Active)
+	if !m.Class.IsNull() && !m.Class.IsUnknown() {
+		defaults.Class = m.Class.ValueString()
+	}
+	if !m.TTL.IsNull() && !m.TTL.IsUnknown() {
+		defaults.TTL = m.TTL.ValueInt64()
+	}
+	return defaults
+}
+
+// setBool sets target to value if value is known and not null
+func setBool(target *bool, value types.Bool) {
+	if !value.IsNull() && !value.IsUnknown() {
+		*target = value.ValueBool()
+	}
+}
+
+// planDefaults sets the planned value of every attribute in defaults that
+// is not set in the configuration to its default. Before the provider is
+// configured the defaults are not known, so such attributes are planned as
+// unknown instead.
+func planDefaults(ctx context.Context, config tfsdk.Config, plan *tfsdk.Plan, defaults map[string]attr.Value, configured bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for name, def := range defaults {
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		if !value.IsNull() {
+			continue
+		}
+
+		if !configured {
+			typ := def.Type(ctx)
+			unknown, err := typ.ValueFromTerraform(ctx, tftypes.NewValue(typ.TerraformType(ctx), tftypes.UnknownValue))
+			if err != nil {
+				diags.AddAttributeError(path.Root(name), "Invalid Default", fmt.Sprintf("Could not plan an unknown default: %s", err))
+				return diags
+			}
+			def = unknown
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root(name), def)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"snitchdns-tf/internal/client"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testModifyPlan runs ModifyPlan for a resource being created with the given
+// configuration, all other attributes being null, and returns the plan
+func testModifyPlan(t *testing.T, r fwresource.ResourceWithModifyPlan, attrs map[string]tftypes.Value) tfsdk.Plan {
+	t.Helper()
+
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// Terraform plans computed attributes that are not configured as unknown
+	plan := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		if a, ok := schemaResp.Schema.Attributes[name]; ok && a.IsComputed() {
+			plan[name] = tftypes.NewValue(typ, tftypes.UnknownValue)
+		}
+	}
+	for name, value := range attrs {
+		plan[name] = value
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testObject(objectType, attrs)},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: testObject(objectType, plan)},
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	return resp.Plan
+}
+
+// TestProviderConfigureResourceDefaults tests that the zone_defaults and record_defaults blocks override the built-in defaults
+func TestProviderConfigureResourceDefaults(t *testing.T) {
+	testClearProviderEnv(t)
+	t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+	t.Setenv(envAPIKey, "test-key")
+
+	_, resp := testConfigureProvider(t, nil)
+	data := resp.ResourceData.(*ProviderData)
+	if data.ZoneDefaults != defaultZoneDefaults || data.RecordDefaults != defaultRecordDefaults {
+		t.Errorf("Expected the built-in defaults without blocks, got %+v and %+v", data.ZoneDefaults, data.RecordDefaults)
+	}
+
+	zoneDefaultsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"active": tftypes.Bool, "catch_all": tftypes.Bool, "forwarding": tftypes.Bool, "regex": tftypes.Bool,
+	}}
+	recordDefaultsType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"active": tftypes.Bool, "cls": tftypes.String, "ttl": tftypes.Number,
+	}}
+	_, resp = testConfigureProvider(t, map[string]tftypes.Value{
+		"zone_defaults": tftypes.NewValue(zoneDefaultsType, map[string]tftypes.Value{
+			"active":     tftypes.NewValue(tftypes.Bool, nil),
+			"catch_all":  tftypes.NewValue(tftypes.Bool, true),
+			"forwarding": tftypes.NewValue(tftypes.Bool, nil),
+			"regex":      tftypes.NewValue(tftypes.Bool, nil),
+		}),
+		"record_defaults": tftypes.NewValue(recordDefaultsType, map[string]tftypes.Value{
+			"active": tftypes.NewValue(tftypes.Bool, false),
+			"cls":    tftypes.NewValue(tftypes.String, nil),
+			"ttl":    tftypes.NewValue(tftypes.Number, 300),
+		}),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	data = resp.ResourceData.(*ProviderData)
+	wantZone := ZoneDefaults{Active: true, CatchAll: true}
+	if data.ZoneDefaults != wantZone {
+		t.Errorf("Expected zone defaults %+v, got %+v", wantZone, data.ZoneDefaults)
+	}
+	wantRecord := RecordDefaults{Active: false, Class: "IN", TTL: 300}
+	if data.RecordDefaults != wantRecord {
+		t.Errorf("Expected record defaults %+v, got %+v", wantRecord, data.RecordDefaults)
+	}
+}
+
+// TestZoneModifyPlanDefaults tests that unset zone settings are planned with the defaults
+func TestZoneModifyPlanDefaults(t *testing.T) {
+	ctx := context.Background()
+	config := map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "example.com"),
+		"regex":  tftypes.NewValue(tftypes.Bool, false),
+	}
+
+	tests := []struct {
+		name     string
+		resource *ZoneResource
+		want     map[string]types.Bool
+	}{
+		{
+			name:     "provider not configured",
+			resource: &ZoneResource{},
+			want: map[string]types.Bool{
+				"active":     types.BoolUnknown(),
+				"catch_all":  types.BoolUnknown(),
+				"forwarding": types.BoolUnknown(),
+				"regex":      types.BoolValue(false),
+			},
+		},
+		{
+			name:     "built-in defaults",
+			resource: &ZoneResource{client: &client.Client{}, defaults: defaultZoneDefaults},
+			want: map[string]types.Bool{
+				"active":     types.BoolValue(true),
+				"catch_all":  types.BoolValue(false),
+				"forwarding": types.BoolValue(false),
+				"regex":      types.BoolValue(false),
+			},
+		},
+		{
+			name:     "provider defaults",
+			resource: &ZoneResource{client: &client.Client{}, defaults: ZoneDefaults{Active: false, Forwarding: true, Regex: true}},
+			want: map[string]types.Bool{
+				"active":     types.BoolValue(false),
+				"catch_all":  types.BoolValue(false),
+				"forwarding": types.BoolValue(true),
+				"regex":      types.BoolValue(false),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := testModifyPlan(t, tt.resource, config)
+
+			var data ZoneResourceModel
+			if diags := plan.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			got := map[string]types.Bool{
+				"active":     data.Active,
+				"catch_all":  data.CatchAll,
+				"forwarding": data.Forwarding,
+				"regex":      data.Regex,
+			}
+			for name, want := range tt.want {
+				if !got[name].Equal(want) {
+					t.Errorf("Expected %s to be %s, got %s", name, want, got[name])
+				}
+			}
+		})
+	}
+}
+
+// TestRecordModifyPlanDefaults tests that unset record settings are planned with the defaults
+func TestRecordModifyPlanDefaults(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/records/types":
+			w.Write([]byte(`["A", "AAAA", "TXT"]`))
+		case "/records/classes":
+			w.Write([]byte(`["IN", "CH"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		defaults   RecordDefaults
+		config     map[string]tftypes.Value
+		wantActive bool
+		wantClass  string
+		wantTTL    int64
+	}{
+		{
+			name:       "built-in defaults",
+			defaults:   defaultRecordDefaults,
+			wantActive: true,
+			wantClass:  "IN",
+			wantTTL:    3600,
+		},
+		{
+			name:       "provider defaults",
+			defaults:   RecordDefaults{Active: true, Class: "CH", TTL: 300},
+			wantActive: true,
+			wantClass:  "CH",
+			wantTTL:    300,
+		},
+		{
+			name:     "configured values win",
+			defaults: RecordDefaults{Active: true, Class: "CH", TTL: 300},
+			config: map[string]tftypes.Value{
+				"active": tftypes.NewValue(tftypes.Bool, false),
+				"cls":    tftypes.NewValue(tftypes.String, "IN"),
+				"ttl":    tftypes.NewValue(tftypes.Number, 60),
+			},
+			wantActive: false,
+			wantClass:  "IN",
+			wantTTL:    60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"zone_id": tftypes.NewValue(tftypes.String, "1"),
+				"type":    tftypes.NewValue(tftypes.String, "A"),
+				"data": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"address": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+				}),
+			}
+			for name, value := range tt.config {
+				config[name] = value
+			}
+
+			r := &RecordResource{client: client.NewClient(server.URL, "test-key"), defaults: tt.defaults}
+			plan := testModifyPlan(t, r, config)
+
+			var data RecordResourceModel
+			if diags := plan.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if data.Active.ValueBool() != tt.wantActive || data.Class.ValueString() != tt.wantClass || data.TTL.ValueInt64() != tt.wantTTL {
+				t.Errorf("Expected active=%t cls=%s ttl=%d, got active=%s cls=%s ttl=%s",
+					tt.wantActive, tt.wantClass, tt.wantTTL, data.Active, data.Class, data.TTL)
+			}
+		})
+	}
+}
+
+// TestRecordModifyPlanUnconfigured tests that unset record settings are planned as unknown before the provider is configured
+func TestRecordModifyPlanUnconfigured(t *testing.T) {
+	ctx := context.Background()
+	plan := testModifyPlan(t, &RecordResource{}, map[string]tftypes.Value{
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"type":    tftypes.NewValue(tftypes.String, "A"),
+		"cls":     tftypes.NewValue(tftypes.String, "IN"),
+		"data": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		}),
+	})
+
+	var data RecordResourceModel
+	if diags := plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+	if !data.Active.IsUnknown() || !data.TTL.IsUnknown() {
+		t.Errorf("Expected unknown active and ttl, got active=%s ttl=%s", data.Active, data.TTL)
+	}
+	if data.Class.ValueString() != "IN" {
+		t.Errorf("Expected the configured cls, got %s", data.Class)
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// configFileNames are the names looked for in the snitchdns configuration
+// directory, in order
+var configFileNames = []string{"config.json", "config.yaml", "config.yml"}
+
+// snitchDNSConfigFile is the content of the SnitchDNS configuration file
+type snitchDNSConfigFile struct {
+	Profiles map[string]snitchDNSProfile `json:"profiles" yaml:"profiles"`
+}
+
+// snitchDNSProfile holds the settings of a named profile. The fields match
+// the provider attributes of the same name; unset fields leave the
+// attribute to its environment variable or default.
+type snitchDNSProfile struct {
+	APIURL                string   `json:"api_url" yaml:"api_url"`
+	APIKey                string   `json:"api_key" yaml:"api_key"`
+	APIKeyFile            string   `json:"api_key_file" yaml:"api_key_file"`
+	APIKeyCommand         string   `json:"api_key_command" yaml:"api_key_command"`
+	APIKeyCommandTimeout  string   `json:"api_key_command_timeout" yaml:"api_key_command_timeout"`
+	MaxConcurrentRequests *int64   `json:"max_concurrent_requests" yaml:"max_concurrent_requests"`
+	RequestsPerSecond     *float64 `json:"requests_per_second" yaml:"requests_per_second"`
+	RequestTimeout        string   `json:"request_timeout" yaml:"request_timeout"`
+	MaxRetries            *int64   `json:"max_retries" yaml:"max_retries"`
+	RetryWaitMin          string   `json:"retry_wait_min" yaml:"retry_wait_min"`
+	RetryWaitMax          string   `json:"retry_wait_max" yaml:"retry_wait_max"`
+	UserAgentSuffix       string   `json:"user_agent_suffix" yaml:"user_agent_suffix"`
+	CACertPEM             string   `json:"ca_cert_pem" yaml:"ca_cert_pem"`
+	CACertFile            string   `json:"ca_cert_file" yaml:"ca_cert_file"`
+	ClientCert            string   `json:"client_cert" yaml:"client_cert"`
+	ClientKey             string   `json:"client_key" yaml:"client_key"`
+	InsecureSkipVerify    *bool    `json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
+	ProxyURL              string   `json:"proxy_url" yaml:"proxy_url"`
+	TLSMinVersion         string   `json:"tls_min_version" yaml:"tls_min_version"`
+}
+
+// defaultConfigFile returns the first configuration file that exists in
+// $XDG_CONFIG_HOME/snitchdns, or ~/.config/snitchdns if XDG_CONFIG_HOME is
+// not set. It returns an empty string if there is none.
+func defaultConfigFile() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	for _, name := range configFileNames {
+		candidate := filepath.Join(dir, "snitchdns", name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// loadConfigFile reads a configuration file. The format is chosen by the
+// extension: .json files are JSON, everything else is YAML. Unknown keys are
+// rejected so that typos do not go unnoticed.
+func loadConfigFile(name string) (*snitchDNSConfigFile, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var config snitchDNSConfigFile
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&config)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	// Relative paths in a profile are relative to the configuration file
+	dir := filepath.Dir(name)
+	for profileName, profile := range config.Profiles {
+		if profile.APIKeyFile != "" && !filepath.IsAbs(profile.APIKeyFile) {
+			profile.APIKeyFile = filepath.Join(dir, profile.APIKeyFile)
+		}
+		if profile.CACertFile != "" && !filepath.IsAbs(profile.CACertFile) {
+			profile.CACertFile = filepath.Join(dir, profile.CACertFile)
+		}
+		config.Profiles[profileName] = profile
+	}
+
+	return &config, nil
+}
+
+// applyProfile fills the attributes that are not set in the provider
+// configuration from the profile selected with the profile attribute or
+// SNITCHDNS_PROFILE. Nothing is changed if no profile is selected.
+func applyProfile(diags *diag.Diagnostics, data *SnitchDNSProviderModel) {
+	name := configString(data.Profile, envProfile)
+	if name == "" {
+		return
+	}
+
+	configFile := configString(data.ConfigFile, envConfigFile)
+	if configFile == "" {
+		configFile = defaultConfigFile()
+	}
+	if configFile == "" {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Missing SnitchDNS Configuration File",
+			fmt.Sprintf("Profile %q is selected, but no configuration file was found. "+
+				"Create ~/.config/snitchdns/config.yaml or config.json, or set config_file or the %s environment variable.", name, envConfigFile),
+		)
+		return
+	}
+
+	config, err := loadConfigFile(configFile)
+	if err != nil {
+		attr := "profile"
+		if errors.Is(err, fs.ErrNotExist) {
+			attr = "config_file"
+		}
+		diags.AddAttributeError(
+			path.Root(attr),
+			"Unable to Read SnitchDNS Configuration File",
+			fmt.Sprintf("The provider could not load profile %q: %s", name, err),
+		)
+		return
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		var names []string
+		for n := range config.Profiles {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unknown SnitchDNS Profile",
+			fmt.Sprintf("Profile %q is not defined in %s. Available profiles: %s.", name, configFile, strings.Join(names, ", ")),
+		)
+		return
+	}
+
+	if err := profile.validate(); err != nil {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Invalid SnitchDNS Profile",
+			fmt.Sprintf("Profile %q in %s is invalid: %s", name, configFile, err),
+		)
+		return
+	}
+
+	profile.applyTo(data)
+}
+
+// validate checks that at most one API key source is set and checks the
+// numeric settings, which are not checked again when the client is
+// configured. Strings are checked like environment variables.
+func (p snitchDNSProfile) validate() error {
+	keySources := 0
+	for _, v := range []string{p.APIKey, p.APIKeyFile, p.APIKeyCommand} {
+		if v != "" {
+			keySources++
+		}
+	}
+	if keySources > 1 {
+		return errors.New("only one of api_key, api_key_file and api_key_command may be set")
+	}
+	if p.MaxConcurrentRequests != nil && (*p.MaxConcurrentRequests < 0 || *p.MaxConcurrentRequests > math.MaxInt32) {
+		return fmt.Errorf("max_concurrent_requests must be between 0 and %d", math.MaxInt32)
+	}
+	if p.RequestsPerSecond != nil && *p.RequestsPerSecond < 0 {
+		return errors.New("requests_per_second must not be negative")
+	}
+	if p.MaxRetries != nil && (*p.MaxRetries < 0 || *p.MaxRetries > maxMaxRetries) {
+		return fmt.Errorf("max_retries must be between 0 and %d", maxMaxRetries)
+	}
+	return nil
+}
+
+// applyTo copies the profile into the attributes of data that are null.
+// The API key sources and the client certificate and key are each taken
+// as a group, so that a profile never mixes with a partial configuration.
+func (p snitchDNSProfile) applyTo(data *SnitchDNSProviderModel) {
+	setString := func(target *types.String, value string) {
+		if target.IsNull() && value != "" {
+			*target = types.StringValue(value)
+		}
+	}
+
+	setString(&data.APIUrl, p.APIURL)
+	if data.APIKey.IsNull() && data.APIKeyFile.IsNull() && data.APIKeyCommand.IsNull() {
+		setString(&data.APIKey, p.APIKey)
+		setString(&data.APIKeyFile, p.APIKeyFile)
+		setString(&data.APIKeyCommand, p.APIKeyCommand)
+	}
+	setString(&data.APIKeyCommandTimeout, p.APIKeyCommandTimeout)
+	setString(&data.RequestTimeout, p.RequestTimeout)
+	setString(&data.RetryWaitMin, p.RetryWaitMin)
+	setString(&data.RetryWaitMax, p.RetryWaitMax)
+	setString(&data.UserAgentSuffix, p.UserAgentSuffix)
+	setString(&data.CACertPEM, p.CACertPEM)
+	setString(&data.CACertFile, p.CACertFile)
+	if data.ClientCert.IsNull() && data.ClientKey.IsNull() {
+		setString(&data.ClientCert, p.ClientCert)
+		setString(&data.ClientKey, p.ClientKey)
+	}
+	setString(&data.ProxyURL, p.ProxyURL)
+	setString(&data.TLSMinVersion, p.TLSMinVersion)
+
+	if data.MaxConcurrentRequests.IsNull() && p.MaxConcurrentRequests != nil {
+		data.MaxConcurrentRequests = types.Int64Value(*p.MaxConcurrentRequests)
+	}
+	if data.RequestsPerSecond.IsNull() && p.RequestsPerSecond != nil {
+		data.RequestsPerSecond = types.Float64Value(*p.RequestsPerSecond)
+	}
+	if data.MaxRetries.IsNull() && p.MaxRetries != nil {
+		data.MaxRetries = types.Int64Value(*p.MaxRetries)
+	}
+	if data.InsecureSkipVerify.IsNull() && p.InsecureSkipVerify != nil {
+		data.InsecureSkipVerify = types.BoolValue(*p.InsecureSkipVerify)
+	}
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testProfilesYAML = `
+profiles:
+  lab:
+    api_url: http://lab.example.com/api/v1
+    api_key_file: lab.key
+    request_timeout: 5s
+    max_retries: 0
+  prod:
+    api_url: https://dns.example.com/api/v1
+    api_key: prod-key
+    insecure_skip_verify: false
+`
+
+// testWriteConfigFile writes a configuration file into a new directory and returns its path
+func testWriteConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "lab.key"), []byte("lab-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, name)
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// TestProviderConfigureProfile tests that a selected profile fills unset attributes
+func TestProviderConfigureProfile(t *testing.T) {
+	configFile := testWriteConfigFile(t, "config.yaml", testProfilesYAML)
+
+	testClearProviderEnv(t)
+	t.Setenv(envConfigFile, configFile)
+	t.Setenv(envProfile, "lab")
+
+	c, resp := testConfigureProvider(t, nil)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if c.BaseURL != "http://lab.example.com/api/v1" {
+		t.Errorf("Expected the URL of the lab profile, got %q", c.BaseURL)
+	}
+	if c.APIKey != "lab-key" {
+		t.Errorf("Expected the key from the file next to the configuration, got %q", c.APIKey)
+	}
+	if c.HTTPClient.Timeout != 5*time.Second || c.MaxRetries != 0 {
+		t.Errorf("Expected timeout 5s and no retries, got %s and %d", c.HTTPClient.Timeout, c.MaxRetries)
+	}
+}
+
+// TestProviderConfigureProfilePrecedence tests that attributes override the
+// profile and the profile overrides environment variables
+func TestProviderConfigureProfilePrecedence(t *testing.T) {
+	configFile := testWriteConfigFile(t, "config.yaml", testProfilesYAML)
+
+	testClearProviderEnv(t)
+	t.Setenv(envAPIURL, "http://env.example.com/api/v1")
+	t.Setenv(envAPIKey, "env-key")
+	t.Setenv(envMaxRetries, "5")
+
+	c, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"profile":         tftypes.NewValue(tftypes.String, "lab"),
+		"config_file":     tftypes.NewValue(tftypes.String, configFile),
+		"api_key_command": tftypes.NewValue(tftypes.String, "echo attr-key"),
+		"request_timeout": tftypes.NewValue(tftypes.String, "1m"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if c.BaseURL != "http://lab.example.com/api/v1" {
+		t.Errorf("Expected the profile URL to win over the environment, got %q", c.BaseURL)
+	}
+	if c.APIKey != "attr-key" {
+		t.Errorf("Expected the key source from the provider block, got %q", c.APIKey)
+	}
+	if c.HTTPClient.Timeout != time.Minute {
+		t.Errorf("Expected request_timeout from the provider block, got %s", c.HTTPClient.Timeout)
+	}
+	if c.MaxRetries != 0 {
+		t.Errorf("Expected max_retries from the profile, got %d", c.MaxRetries)
+	}
+}
+
+// TestProviderConfigureProfileJSON tests JSON configuration files in the default location
+func TestProviderConfigureProfileJSON(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, "snitchdns"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	config := `{"profiles": {"sandbox": {"api_url": "http://sandbox.example.com/api/v1", "api_key": "sandbox-key", "max_concurrent_requests": 1}}}`
+	if err := os.WriteFile(filepath.Join(home, "snitchdns", "config.json"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testClearProviderEnv(t)
+	t.Setenv("XDG_CONFIG_HOME", home)
+
+	c, resp := testConfigureProvider(t, map[string]tftypes.Value{
+		"profile": tftypes.NewValue(tftypes.String, "sandbox"),
+	})
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if c.APIKey != "sandbox-key" || c.MaxConcurrency != 1 {
+		t.Errorf("Expected the sandbox profile, got key %q and concurrency %d", c.APIKey, c.MaxConcurrency)
+	}
+}
+
+// TestProviderConfigureProfileErrors tests that problems with the configuration file are reported
+func TestProviderConfigureProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		profile string
+		want    string
+	}{
+		{"unknown profile", "config.yaml", testProfilesYAML, "staging", "Available profiles: lab, prod"},
+		{"unknown key", "config.yaml", "profiles:\n  lab:\n    api_uri: http://lab\n", "lab", "api_uri"},
+		{"invalid JSON", "config.json", `{"profiles": `, "lab", "failed to parse"},
+		{"several keys", "config.yaml", "profiles:\n  lab:\n    api_key: a\n    api_key_file: b\n", "lab", "only one of api_key"},
+		{"retries", "config.yaml", "profiles:\n  lab:\n    max_retries: 99\n", "lab", "max_retries must be between"},
+		{"missing file", "", "", "lab", "no such file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := filepath.Join(t.TempDir(), "missing.yaml")
+			if tt.file != "" {
+				configFile = testWriteConfigFile(t, tt.file, tt.content)
+			}
+
+			testClearProviderEnv(t)
+			t.Setenv(envAPIURL, "http://localhost:8000/api/v1")
+			t.Setenv(envAPIKey, "test-key")
+			t.Setenv(envConfigFile, configFile)
+			t.Setenv(envProfile, tt.profile)
+
+			_, resp := testConfigureProvider(t, nil)
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("Expected 1 error, got: %v", resp.Diagnostics)
+			}
+			if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, tt.want) {
+				t.Errorf("Expected error containing %q, got %q", tt.want, detail)
+			}
+		})
+	}
+}
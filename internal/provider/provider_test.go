@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"snitchdns-tf/internal/testcontainer"
 )
 
@@ -15,3 +16,16 @@ func testAccProtoV6ProviderFactories(container *testcontainer.SnitchDNSContainer
 		"snitchdns": providerserver.NewProtocol6WithError(New("test", container)()),
 	}
 }
+
+// testObject returns a value of objectType with every attribute null except
+// those set in attrs, as a minimal configuration, plan or state of a schema
+func testObject(objectType tftypes.Object, attrs map[string]tftypes.Value) tftypes.Value {
+	raw := map[string]tftypes.Value{}
+	for name, typ := range objectType.AttributeTypes {
+		raw[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attrs {
+		raw[name] = value
+	}
+	return tftypes.NewValue(objectType, raw)
+}
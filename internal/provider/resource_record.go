@@ -3,22 +3,37 @@ package provider
 import (
 	"context"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"snitchdns-tf/internal/client"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &RecordResource{}
 var _ resource.ResourceWithImportState = &RecordResource{}
+var _ resource.ResourceWithModifyPlan = &RecordResource{}
+var _ resource.ResourceWithValidateConfig = &RecordResource{}
+
+// recordMnemonicRegex is the syntax of record types and classes. Whether a
+// value is actually supported is checked against the server in ModifyPlan.
+var recordMnemonicRegex = regexp.MustCompile(`^[A-Z][A-Z0-9]*$`)
 
 // NewRecordResource creates a new Record resource.
 func NewRecordResource() resource.Resource {
@@ -27,7 +42,8 @@ func NewRecordResource() resource.Resource {
 
 // RecordResource defines the resource implementation.
 type RecordResource struct {
-	client *client.Client
+	client   *client.Client
+	defaults RecordDefaults
 }
 
 // RecordResourceModel describes the resource data model.
@@ -44,6 +60,8 @@ type RecordResourceModel struct {
 	ConditionalLimit types.Int64    `tfsdk:"conditional_limit"`
 	ConditionalReset types.Bool     `tfsdk:"conditional_reset"`
 	ConditionalData  types.Map      `tfsdk:"conditional_data"`
+	ObservedCount    types.Int64    `tfsdk:"observed_count"`
+	ResetTrigger     types.String   `tfsdk:"reset_counter_trigger"`
 	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -73,29 +91,32 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"active": schema.BoolAttribute{
-				Required:            true,
-				MarkdownDescription: "Whether the record is active and will respond to DNS queries. Set to `false` to temporarily disable without deleting.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the record is active and will respond to DNS queries. Set to `false` to temporarily disable without deleting. Defaults to `true`, or the value set in the provider's `record_defaults` block.",
 			},
 			"cls": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "DNS class for the record. Typically `IN` (Internet), but can also be `CH` (Chaos) or `HS` (Hesiod).",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "DNS class for the record. Typically `IN` (Internet), but can also be `CH` (Chaos) or `HS` (Hesiod). Validated during plan against the classes published by the server. Defaults to `IN`, or the value set in the provider's `record_defaults` block.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("IN", "CH", "HS"),
+					stringvalidator.RegexMatches(recordMnemonicRegex, "must be an upper-case DNS class such as IN"),
 				},
 			},
 			"type": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "DNS record type. Supported types: A, AAAA, AFSDB, CAA, CNAME, DNAME, HINFO, MX, NAPTR, NS, PTR, RP, SOA, SPF, SRV, SSHFP, TSIG, TXT.",
+				MarkdownDescription: "DNS record type such as A, AAAA, CNAME, MX or TXT. Validated during plan against the types published by the server; see the `snitchdns_record_types` data source.",
 				Validators: []validator.String{
-					stringvalidator.OneOf("A", "AAAA", "AFSDB", "CAA", "CNAME", "DNAME", "HINFO", "MX", "NAPTR", "NS", "PTR", "RP", "SOA", "SPF", "SRV", "SSHFP", "TSIG", "TXT"),
+					stringvalidator.RegexMatches(recordMnemonicRegex, "must be an upper-case DNS record type such as A or TXT"),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				Required:            true,
-				MarkdownDescription: "Time to live in seconds. Determines how long DNS resolvers should cache this record. Typical values range from 60 (1 minute) to 86400 (1 day).",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Time to live in seconds. Determines how long DNS resolvers should cache this record. Typical values range from 60 (1 minute) to 86400 (1 day). Defaults to `3600`, or the value set in the provider's `record_defaults` block.",
 				Validators: []validator.Int64{
 					int64validator.Between(1, 2147483647), // Max 32-bit int for TTL
 				},
@@ -113,12 +134,29 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 			"conditional_count": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Current query count for conditional logic. Automatically incremented by SnitchDNS when the record is queried.",
+				MarkdownDescription: "Starting value of the query counter for conditional logic. Only sent when the record is created or `reset_counter_trigger` changes, so the counts SnitchDNS adds on every query never show up as drift. Defaults to `0`. The live counter is `observed_count`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"observed_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Query counter as last read from SnitchDNS. Incremented by SnitchDNS when the record is queried.",
+			},
+			"reset_counter_trigger": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary value that resets the query counter to `conditional_count` whenever it changes to a new value, e.g. a timestamp or a release version.",
 			},
 			"conditional_limit": schema.Int64Attribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Query limit for conditional responses. When `conditional_count` reaches this limit, the conditional behavior triggers.",
+				MarkdownDescription: "Query limit for conditional responses. When `conditional_count` reaches this limit, the conditional behavior triggers. Required if `is_conditional` is `true`; must be positive.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"conditional_reset": schema.BoolAttribute{
 				Optional:            true,
@@ -129,7 +167,7 @@ func (r *RecordResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "Alternative data to return when conditional limit is reached. Uses the same format as the `data` attribute.",
+				MarkdownDescription: "Alternative data to return when conditional limit is reached. Uses the same format as the `data` attribute and must have the same keys. Required if `is_conditional` is `true`.",
 			},
 		},
 		Blocks: map[string]schema.Block{
@@ -149,14 +187,151 @@ func (r *RecordResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+	r.defaults = data.RecordDefaults
+}
+
+// ModifyPlan fills in the defaults of the settings that are not configured
+// and validates type and cls against the lists published by the server, so
+// that record types added in newer SnitchDNS versions work without a
+// provider release.
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaults(ctx, req.Config, &resp.Plan, map[string]attr.Value{
+		"active": types.BoolValue(r.defaults.Active),
+		"cls":    types.StringValue(r.defaults.Class),
+		"ttl":    types.Int64Value(r.defaults.TTL),
+	}, r.client != nil)...)
+	// The server's lists are not known before the provider is configured
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	var recordType, class types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("cls"), &class)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateServerValue(ctx, path.Root("type"), "record type", recordType, r.client.RecordTypes)...)
+	resp.Diagnostics.Append(validateServerValue(ctx, path.Root("cls"), "record class", class, r.client.RecordClasses)...)
+}
+
+// ValidateConfig checks the conditional attributes: they may only be set on
+// conditional records, conditional records need a limit and conditional data,
+// and the conditional data must have the same keys as data.
+func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RecordResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.IsConditional.IsUnknown() {
+		return
+	}
+
+	if !data.IsConditional.ValueBool() {
+		conditional := map[string]attr.Value{
+			"conditional_count":     data.ConditionalCount,
+			"conditional_limit":     data.ConditionalLimit,
+			"conditional_reset":     data.ConditionalReset,
+			"conditional_data":      data.ConditionalData,
+			"reset_counter_trigger": data.ResetTrigger,
+		}
+		for _, name := range slices.Sorted(maps.Keys(conditional)) {
+			// Unknown values may still turn out null
+			if conditional[name].IsNull() || conditional[name].IsUnknown() {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("The attribute %q only applies to conditional records. Set is_conditional = true or remove it.", name),
+			)
+		}
+		return
+	}
+
+	if data.ConditionalLimit.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("conditional_limit"),
+			"Missing Attribute Configuration",
+			"Conditional records require conditional_limit, the number of queries after which conditional_data is returned.",
+		)
+	}
+	if data.ConditionalData.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("conditional_data"),
+			"Missing Attribute Configuration",
+			"Conditional records require conditional_data, the data returned once conditional_limit is reached.",
 		)
 		return
 	}
 
-	r.client = client
+	if data.Data.IsUnknown() || data.ConditionalData.IsUnknown() {
+		return
+	}
+
+	keys := data.Data.Elements()
+	conditionalKeys := data.ConditionalData.Elements()
+	for _, key := range slices.Sorted(maps.Keys(conditionalKeys)) {
+		if _, ok := keys[key]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("conditional_data").AtMapKey(key),
+				"Unexpected Conditional Data Key",
+				fmt.Sprintf("The key %q is not in data. conditional_data must have the same keys as data, as both describe a record of the same type.", key),
+			)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(keys)) {
+		if _, ok := conditionalKeys[key]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("conditional_data"),
+				"Missing Conditional Data Key",
+				fmt.Sprintf("The key %q of data is missing. conditional_data must have the same keys as data, as both describe a record of the same type.", key),
+			)
+		}
+	}
+}
+
+// validateServerValue checks a planned value against a list fetched from the
+// server. If the list cannot be fetched, validation is skipped and the API
+// gets the final say during apply.
+func validateServerValue(ctx context.Context, attrPath path.Path, kind string, value types.String, fetch func(context.Context) ([]string, error)) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	allowed, err := fetch(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not fetch supported values from the server, skipping validation", map[string]any{
+			"kind":  kind,
+			"error": err.Error(),
+		})
+		return diags
+	}
+
+	if !slices.Contains(allowed, value.ValueString()) {
+		diags.AddAttributeError(
+			attrPath,
+			"Unsupported "+kind,
+			fmt.Sprintf("The SnitchDNS server does not support the %s %q. Supported values: %s.", kind, value.ValueString(), strings.Join(allowed, ", ")),
+		)
+	}
+
+	return diags
 }
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -67,7 +68,7 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 		ConditionalData:  conditionalDataMap,
 	}
 
-	record, err := r.client.CreateRecord(data.ZoneID.ValueString(), createReq)
+	record, err := r.client.CreateRecord(ctx, data.ZoneID.ValueString(), createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating record",
@@ -84,7 +85,8 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.Type = types.StringValue(record.Type)
 	data.TTL = types.Int64Value(int64(record.TTL))
 	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	data.ConditionalCount = types.Int64Value(int64(createReq.ConditionalCount))
+	data.ObservedCount = types.Int64Value(int64(record.ConditionalCount))
 	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
 	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
 
@@ -144,10 +146,10 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	})
 
 	// Get record from API
-	record, err := r.client.GetRecord(data.ZoneID.ValueString(), data.ID.ValueString())
+	record, err := r.client.GetRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
 	if err != nil {
 		// Check if this is a 404 - resource was deleted outside Terraform
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Record not found, removing from state", map[string]any{
 				"zone_id":   data.ZoneID.ValueString(),
 				"record_id": data.ID.ValueString(),
@@ -164,7 +166,13 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Update data model from API response
+	// Update data model from API response. conditional_count is the
+	// configured starting value and is left alone, as the server increments
+	// the counter on every query; only after import it is taken from the
+	// server.
+	if data.ConditionalCount.IsNull() {
+		data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	}
 	data.ID = types.StringValue(fmt.Sprintf("%d", record.ID))
 	data.ZoneID = types.StringValue(fmt.Sprintf("%d", record.ZoneID))
 	data.Active = types.BoolValue(record.Active)
@@ -172,7 +180,7 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.Type = types.StringValue(record.Type)
 	data.TTL = types.Int64Value(int64(record.TTL))
 	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	data.ObservedCount = types.Int64Value(int64(record.ConditionalCount))
 	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
 	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
 
@@ -253,10 +261,26 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	typ := data.Type.ValueString()
 	ttl := int(data.TTL.ValueInt64())
 	isConditional := data.IsConditional.ValueBool()
-	conditionalCount := int(data.ConditionalCount.ValueInt64())
 	conditionalLimit := int(data.ConditionalLimit.ValueInt64())
 	conditionalReset := data.ConditionalReset.ValueBool()
 
+	// The counter is only reset when reset_counter_trigger changes to a new
+	// value, so that updates do not lose the counts of past queries
+	var priorTrigger types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("reset_counter_trigger"), &priorTrigger)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var conditionalCount *int
+	if !data.ResetTrigger.IsNull() && !data.ResetTrigger.Equal(priorTrigger) {
+		count := int(data.ConditionalCount.ValueInt64())
+		conditionalCount = &count
+		tflog.Debug(ctx, "Resetting conditional counter", map[string]any{
+			"record_id":         data.ID.ValueString(),
+			"conditional_count": count,
+		})
+	}
+
 	updateReq := client.UpdateRecordRequest{
 		Active:           &active,
 		Class:            &cls,
@@ -264,13 +288,13 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		TTL:              &ttl,
 		Data:             dataMap,
 		IsConditional:    &isConditional,
-		ConditionalCount: &conditionalCount,
+		ConditionalCount: conditionalCount,
 		ConditionalLimit: &conditionalLimit,
 		ConditionalReset: &conditionalReset,
 		ConditionalData:  conditionalDataMap,
 	}
 
-	record, err := r.client.UpdateRecord(data.ZoneID.ValueString(), data.ID.ValueString(), updateReq)
+	record, err := r.client.UpdateRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating record",
@@ -287,7 +311,7 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	data.Type = types.StringValue(record.Type)
 	data.TTL = types.Int64Value(int64(record.TTL))
 	data.IsConditional = types.BoolValue(record.IsConditional)
-	data.ConditionalCount = types.Int64Value(int64(record.ConditionalCount))
+	data.ObservedCount = types.Int64Value(int64(record.ConditionalCount))
 	data.ConditionalLimit = types.Int64Value(int64(record.ConditionalLimit))
 	data.ConditionalReset = types.BoolValue(record.ConditionalReset)
 
@@ -342,7 +366,7 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	defer cancel()
 
 	// Delete record via API
-	err := r.client.DeleteRecordWithContext(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	err := r.client.DeleteRecord(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting record",
@@ -2,11 +2,26 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"snitchdns-tf/internal/client"
 	"snitchdns-tf/internal/testcontainer"
 )
 
@@ -99,6 +114,155 @@ func TestAccRecordResource_CNAME(t *testing.T) {
 	})
 }
 
+// TestAccRecordResource_UnsupportedType tests that types unknown to the server fail during plan
+func TestAccRecordResource_UnsupportedType(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	config := strings.Replace(testAccRecordResourceConfigA(container, "unsupported.example.com", "192.0.2.1"), `type    = "A"`, `type    = "NOPE"`, 1)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unsupported record type`),
+			},
+		},
+	})
+}
+
+// TestAccRecordResource_Defaults tests a zone and record that leave their settings to the defaults
+func TestAccRecordResource_Defaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordResourceConfigDefaults(container, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "active", "true"),
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "catch_all", "false"),
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "forwarding", "false"),
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "regex", "false"),
+					resource.TestCheckResourceAttr("snitchdns_record.test", "active", "true"),
+					resource.TestCheckResourceAttr("snitchdns_record.test", "cls", "IN"),
+					resource.TestCheckResourceAttr("snitchdns_record.test", "ttl", "3600"),
+				),
+			},
+			// Provider defaults apply to existing resources
+			{
+				Config: testAccRecordResourceConfigDefaults(container, `
+  record_defaults {
+    ttl = 300
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_record.test", "ttl", "300"),
+				),
+			},
+			{
+				Config: testAccRecordResourceConfigDefaults(container, `
+  record_defaults {
+    ttl = 300
+  }
+`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// TestAccRecordResource_ConditionalCounter tests that queries counted by the server never cause a plan diff
+func TestAccRecordResource_ConditionalCounter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	apiClient := client.NewClient(container.GetAPIEndpoint(), container.APIKey)
+	var zoneID, recordID string
+
+	// simulateQueries sets the counter as if the record had been queried
+	simulateQueries := func(count int) func() {
+		return func() {
+			if _, err := apiClient.UpdateRecord(ctx, zoneID, recordID, client.UpdateRecordRequest{ConditionalCount: &count}); err != nil {
+				t.Fatalf("Failed to update the counter: %v", err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecordResourceConfigConditional(container, "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_record.test", "conditional_count", "0"),
+					resource.TestCheckResourceAttr("snitchdns_record.test", "observed_count", "0"),
+					testAccCaptureAttr("snitchdns_zone.test", "id", &zoneID),
+					testAccCaptureAttr("snitchdns_record.test", "id", &recordID),
+				),
+			},
+			// Queries only change observed_count
+			{
+				PreConfig: simulateQueries(17),
+				Config:    testAccRecordResourceConfigConditional(container, "v1"),
+				PlanOnly:  true,
+			},
+			{
+				Config: testAccRecordResourceConfigConditional(container, "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_record.test", "conditional_count", "0"),
+					resource.TestCheckResourceAttr("snitchdns_record.test", "observed_count", "17"),
+				),
+			},
+			// A new trigger value resets the counter
+			{
+				PreConfig: simulateQueries(23),
+				Config:    testAccRecordResourceConfigConditional(container, "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_record.test", "observed_count", "0"),
+				),
+			},
+		},
+	})
+}
+
 // testAccRecordImportStateIdFunc returns the import ID in format "zone_id:record_id"
 func testAccRecordImportStateIdFunc(s *terraform.State) (string, error) {
 	rs, ok := s.RootModule().Resources["snitchdns_record.test"]
@@ -142,6 +306,61 @@ resource "snitchdns_record" "test" {
 `, container.GetAPIEndpoint(), container.APIKey, domain, address)
 }
 
+// testAccRecordResourceConfigDefaults generates HCL configuration for a zone and an A record without optional settings
+func testAccRecordResourceConfigDefaults(container *testcontainer.SnitchDNSContainer, providerBlocks string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+%[3]s}
+
+resource "snitchdns_zone" "test" {
+  domain = "defaults.example.com"
+}
+
+resource "snitchdns_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+
+  data = {
+    address = "192.0.2.1"
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey, providerBlocks)
+}
+
+// testAccRecordResourceConfigConditional generates HCL configuration for a conditional A record
+func testAccRecordResourceConfigConditional(container *testcontainer.SnitchDNSContainer, trigger string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain = "conditional.example.com"
+}
+
+resource "snitchdns_record" "test" {
+  zone_id = snitchdns_zone.test.id
+  type    = "A"
+
+  data = {
+    address = "192.0.2.1"
+  }
+
+  is_conditional        = true
+  conditional_count     = 0
+  conditional_limit     = 100
+  reset_counter_trigger = %[3]q
+
+  conditional_data = {
+    address = "192.0.2.2"
+  }
+}
+`, container.GetAPIEndpoint(), container.APIKey, trigger)
+}
+
 // testAccRecordResourceConfigCNAME generates HCL configuration for CNAME record testing
 func testAccRecordResourceConfigCNAME(container *testcontainer.SnitchDNSContainer, domain string, target string) string {
 	return fmt.Sprintf(`
@@ -171,3 +390,377 @@ resource "snitchdns_record" "test" {
 }
 `, container.GetAPIEndpoint(), container.APIKey, domain, target)
 }
+
+// TestValidateServerValue tests plan-time validation against the lists published by the server
+func TestValidateServerValue(t *testing.T) {
+	serverTypes := func(context.Context) ([]string, error) {
+		return []string{"A", "AAAA", "HTTPS"}, nil
+	}
+	unreachable := func(context.Context) ([]string, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	tests := []struct {
+		name      string
+		value     types.String
+		fetch     func(context.Context) ([]string, error)
+		wantError bool
+	}{
+		{"supported", types.StringValue("A"), serverTypes, false},
+		{"newer server type", types.StringValue("HTTPS"), serverTypes, false},
+		{"unsupported", types.StringValue("TSIG"), serverTypes, true},
+		{"unknown value", types.StringUnknown(), serverTypes, false},
+		{"server unreachable", types.StringValue("TSIG"), unreachable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateServerValue(context.Background(), path.Root("type"), "record type", tt.value, tt.fetch)
+			if diags.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
+
+// testConditionalRecordJSON is a conditional record as returned by the API
+// after it was queried 42 times
+const testConditionalRecordJSON = `{"id": 2, "zone_id": 1, "active": true, "cls": "IN", "type": "A", "ttl": 300,
+  "data": "{\"address\": \"192.0.2.1\"}", "is_conditional": true, "conditional_count": 42,
+  "conditional_limit": 100, "conditional_data": "{\"address\": \"192.0.2.2\"}"}`
+
+// testRecordObject builds a snitchdns_record value with the given attributes, all others being null
+func testRecordObject(ctx context.Context, r *RecordResource, attrs map[string]tftypes.Value) (fwresource.SchemaResponse, tftypes.Value) {
+	schemaResp := fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	return schemaResp, testObject(objectType, attrs)
+}
+
+// TestRecordReadConditionalCount tests that queries counted by the server never change conditional_count
+func TestRecordReadConditionalCount(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(testConditionalRecordJSON))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		count     tftypes.Value
+		wantCount int64
+	}{
+		{"configured start value", tftypes.NewValue(tftypes.Number, 5), 5},
+		{"import", tftypes.NewValue(tftypes.Number, nil), 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RecordResource{client: client.NewClient(server.URL, "test-key")}
+			schemaResp, state := testRecordObject(ctx, r, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, "2"),
+				"zone_id":           tftypes.NewValue(tftypes.String, "1"),
+				"conditional_count": tt.count,
+			})
+
+			req := fwresource.ReadRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+			resp := &fwresource.ReadResponse{State: req.State}
+			r.Read(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data RecordResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.ConditionalCount.ValueInt64() != tt.wantCount {
+				t.Errorf("Expected conditional_count %d, got %s", tt.wantCount, data.ConditionalCount)
+			}
+			if data.ObservedCount.ValueInt64() != 42 {
+				t.Errorf("Expected observed_count 42, got %s", data.ObservedCount)
+			}
+		})
+	}
+}
+
+// TestRecordUpdateResetCounterTrigger tests that the counter is only sent when reset_counter_trigger changes
+func TestRecordUpdateResetCounterTrigger(t *testing.T) {
+	ctx := context.Background()
+
+	var sent map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = nil
+		if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		w.Write([]byte(testConditionalRecordJSON))
+	}))
+	defer server.Close()
+
+	trigger := func(v any) tftypes.Value { return tftypes.NewValue(tftypes.String, v) }
+	tests := []struct {
+		name      string
+		prior     tftypes.Value
+		planned   tftypes.Value
+		wantReset bool
+	}{
+		{"no trigger", trigger(nil), trigger(nil), false},
+		{"unchanged", trigger("v1"), trigger("v1"), false},
+		{"changed", trigger("v1"), trigger("v2"), true},
+		{"added", trigger(nil), trigger("v1"), true},
+		{"removed", trigger("v1"), trigger(nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RecordResource{client: client.NewClient(server.URL, "test-key")}
+			attrs := map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, "2"),
+				"zone_id":           tftypes.NewValue(tftypes.String, "1"),
+				"active":            tftypes.NewValue(tftypes.Bool, true),
+				"cls":               tftypes.NewValue(tftypes.String, "IN"),
+				"type":              tftypes.NewValue(tftypes.String, "A"),
+				"ttl":               tftypes.NewValue(tftypes.Number, 300),
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, true),
+				"conditional_count": tftypes.NewValue(tftypes.Number, 5),
+				"conditional_limit": tftypes.NewValue(tftypes.Number, 100),
+				"data": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"address": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+				}),
+			}
+			attrs["reset_counter_trigger"] = tt.prior
+			schemaResp, state := testRecordObject(ctx, r, attrs)
+			attrs["reset_counter_trigger"] = tt.planned
+			_, plan := testRecordObject(ctx, r, attrs)
+
+			req := fwresource.UpdateRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+				Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: plan},
+			}
+			resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: state}}
+			r.Update(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			count, reset := sent["conditional_count"]
+			if reset != tt.wantReset {
+				t.Errorf("Expected conditional_count sent=%t, got request %v", tt.wantReset, sent)
+			}
+			if reset && count != float64(5) {
+				t.Errorf("Expected conditional_count 5 to be sent, got %v", count)
+			}
+
+			var data RecordResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if data.ConditionalCount.ValueInt64() != 5 || data.ObservedCount.ValueInt64() != 42 {
+				t.Errorf("Expected conditional_count 5 and observed_count 42, got %s and %s", data.ConditionalCount, data.ObservedCount)
+			}
+		})
+	}
+}
+
+// TestRecordPlanKeepsConditionalCount tests that an update plan keeps
+// conditional_count at its state value while the observed counter is unknown
+func TestRecordPlanKeepsConditionalCount(t *testing.T) {
+	ctx := context.Background()
+	schemaResp, _ := testRecordObject(ctx, &RecordResource{}, nil)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	address := tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"address": tftypes.NewValue(tftypes.String, "192.0.2.1"),
+	})
+
+	config := map[string]tftypes.Value{
+		"zone_id":           tftypes.NewValue(tftypes.String, "1"),
+		"active":            tftypes.NewValue(tftypes.Bool, true),
+		"cls":               tftypes.NewValue(tftypes.String, "IN"),
+		"type":              tftypes.NewValue(tftypes.String, "A"),
+		"ttl":               tftypes.NewValue(tftypes.Number, 600),
+		"data":              address,
+		"is_conditional":    tftypes.NewValue(tftypes.Bool, true),
+		"conditional_limit": tftypes.NewValue(tftypes.Number, 100),
+		"conditional_data":  address,
+	}
+	state := map[string]tftypes.Value{
+		"id":                tftypes.NewValue(tftypes.String, "2"),
+		"conditional_count": tftypes.NewValue(tftypes.Number, 5),
+		"observed_count":    tftypes.NewValue(tftypes.Number, 42),
+		"conditional_reset": tftypes.NewValue(tftypes.Bool, false),
+	}
+	for name, value := range config {
+		state[name] = value
+	}
+	state["ttl"] = tftypes.NewValue(tftypes.Number, 300)
+	// Terraform proposes the prior state for computed attributes that are not configured
+	proposed := map[string]tftypes.Value{}
+	for name, value := range state {
+		proposed[name] = value
+	}
+	proposed["ttl"] = config["ttl"]
+
+	dynamicValue := func(attrs map[string]tftypes.Value) *tfprotov6.DynamicValue {
+		v, err := tfprotov6.NewDynamicValue(objectType, testObject(objectType, attrs))
+		if err != nil {
+			t.Fatalf("Failed to encode value: %v", err)
+		}
+		return &v
+	}
+
+	server := providerserver.NewProtocol6(New("test", nil)())()
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "snitchdns_record",
+		Config:           dynamicValue(config),
+		PriorState:       dynamicValue(state),
+		ProposedNewState: dynamicValue(proposed),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("Failed to decode plan: %v", err)
+	}
+	var data RecordResourceModel
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned}
+	if diags := plan.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	if data.TTL.ValueInt64() != 600 {
+		t.Errorf("Expected ttl 600 to be planned, got %s", data.TTL)
+	}
+	if data.ConditionalCount.ValueInt64() != 5 {
+		t.Errorf("Expected conditional_count 5 from the state, got %s", data.ConditionalCount)
+	}
+	if !data.ObservedCount.IsUnknown() {
+		t.Errorf("Expected observed_count to be unknown, got %s", data.ObservedCount)
+	}
+	if len(resp.RequiresReplace) != 0 {
+		t.Errorf("Expected an in-place update, got replacement for %v", resp.RequiresReplace)
+	}
+}
+
+// TestRecordValidateConfig tests the cross-field validation of the conditional attributes
+func TestRecordValidateConfig(t *testing.T) {
+	ctx := context.Background()
+	stringMap := func(values map[string]string) tftypes.Value {
+		elements := map[string]tftypes.Value{}
+		for k, v := range values {
+			elements[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+	}
+
+	tests := []struct {
+		name      string
+		config    map[string]tftypes.Value
+		wantPaths []path.Path
+	}{
+		{
+			name: "plain record",
+		},
+		{
+			name: "conditional record",
+			config: map[string]tftypes.Value{
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, true),
+				"conditional_limit": tftypes.NewValue(tftypes.Number, 10),
+				"conditional_data":  stringMap(map[string]string{"address": "192.0.2.2"}),
+			},
+		},
+		{
+			name: "conditional attributes without is_conditional",
+			config: map[string]tftypes.Value{
+				"conditional_limit":     tftypes.NewValue(tftypes.Number, 10),
+				"conditional_data":      stringMap(map[string]string{"address": "192.0.2.2"}),
+				"reset_counter_trigger": tftypes.NewValue(tftypes.String, "v1"),
+			},
+			wantPaths: []path.Path{
+				path.Root("conditional_data"),
+				path.Root("conditional_limit"),
+				path.Root("reset_counter_trigger"),
+			},
+		},
+		{
+			name: "is_conditional false",
+			config: map[string]tftypes.Value{
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, false),
+				"conditional_reset": tftypes.NewValue(tftypes.Bool, true),
+			},
+			wantPaths: []path.Path{path.Root("conditional_reset")},
+		},
+		{
+			name: "unknown conditional attributes without is_conditional",
+			config: map[string]tftypes.Value{
+				"conditional_limit":     tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+				"conditional_data":      tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue),
+				"reset_counter_trigger": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+		{
+			name: "is_conditional unknown",
+			config: map[string]tftypes.Value{
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+				"conditional_limit": tftypes.NewValue(tftypes.Number, 10),
+			},
+		},
+		{
+			name: "missing limit and data",
+			config: map[string]tftypes.Value{
+				"is_conditional": tftypes.NewValue(tftypes.Bool, true),
+			},
+			wantPaths: []path.Path{path.Root("conditional_limit"), path.Root("conditional_data")},
+		},
+		{
+			name: "mismatched keys",
+			config: map[string]tftypes.Value{
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, true),
+				"conditional_limit": tftypes.NewValue(tftypes.Number, 10),
+				"conditional_data":  stringMap(map[string]string{"hostname": "mail.example.com"}),
+			},
+			wantPaths: []path.Path{path.Root("conditional_data").AtMapKey("hostname"), path.Root("conditional_data")},
+		},
+		{
+			name: "unknown conditional data",
+			config: map[string]tftypes.Value{
+				"is_conditional":    tftypes.NewValue(tftypes.Bool, true),
+				"conditional_limit": tftypes.NewValue(tftypes.Number, 10),
+				"conditional_data":  tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"zone_id": tftypes.NewValue(tftypes.String, "1"),
+				"type":    tftypes.NewValue(tftypes.String, "A"),
+				"data":    stringMap(map[string]string{"address": "192.0.2.1"}),
+			}
+			for name, value := range tt.config {
+				config[name] = value
+			}
+
+			r := &RecordResource{}
+			schemaResp, raw := testRecordObject(ctx, r, config)
+			req := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(ctx, req, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != len(tt.wantPaths) {
+				t.Fatalf("Expected %d errors, got %d: %v", len(tt.wantPaths), len(errs), errs)
+			}
+			for i, want := range tt.wantPaths {
+				got, ok := errs[i].(diag.DiagnosticWithPath)
+				if !ok || !got.Path().Equal(want) {
+					t.Errorf("Expected error %d at %s, got %v", i, want, errs[i])
+				}
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,6 +19,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ZoneResource{}
 var _ resource.ResourceWithImportState = &ZoneResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneResource{}
 
 // NewZoneResource creates a new Zone resource.
 func NewZoneResource() resource.Resource {
@@ -26,7 +28,9 @@ func NewZoneResource() resource.Resource {
 
 // ZoneResource defines the resource implementation.
 type ZoneResource struct {
-	client *client.Client
+	client      *client.Client
+	defaultTags []string
+	defaults    ZoneDefaults
 }
 
 // ZoneResourceModel describes the resource data model.
@@ -39,7 +43,8 @@ type ZoneResourceModel struct {
 	Forwarding types.Bool     `tfsdk:"forwarding"`
 	Regex      types.Bool     `tfsdk:"regex"`
 	Master     types.Bool     `tfsdk:"master"`
-	Tags       types.List     `tfsdk:"tags"`
+	Tags       types.Set      `tfsdk:"tags"`
+	TagsAll    types.Set      `tfsdk:"tags_all"`
 	CreatedAt  types.String   `tfsdk:"created_at"`
 	UpdatedAt  types.String   `tfsdk:"updated_at"`
 	Timeouts   timeouts.Value `tfsdk:"timeouts"`
@@ -53,6 +58,8 @@ func (r *ZoneResource) Metadata(_ context.Context, req resource.MetadataRequest,
 // Schema defines the resource schema.
 func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed tags and tags_all from lists to sets
+		Version:             1,
 		MarkdownDescription: "Manages a DNS zone in SnitchDNS. Zones are containers for DNS records and can be configured with various options like catch-all, forwarding, and regex matching.",
 
 		Attributes: map[string]schema.Attribute{
@@ -75,29 +82,41 @@ func (r *ZoneResource) Schema(ctx context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"active": schema.BoolAttribute{
-				MarkdownDescription: "Whether the zone is active and will respond to DNS queries. Set to `false` to disable the zone without deleting it.",
-				Required:            true,
+				MarkdownDescription: "Whether the zone is active and will respond to DNS queries. Set to `false` to disable the zone without deleting it. Defaults to `true`, or the value set in the provider's `zone_defaults` block.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"catch_all": schema.BoolAttribute{
-				MarkdownDescription: "Enable catch-all DNS queries for this zone. When enabled, the zone will respond to queries for any subdomain, even if no specific record exists.",
-				Required:            true,
+				MarkdownDescription: "Enable catch-all DNS queries for this zone. When enabled, the zone will respond to queries for any subdomain, even if no specific record exists. Defaults to `false`, or the value set in the provider's `zone_defaults` block.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"forwarding": schema.BoolAttribute{
-				MarkdownDescription: "Enable DNS forwarding to upstream DNS servers. When enabled, unmatched queries will be forwarded to a configured upstream resolver.",
-				Required:            true,
+				MarkdownDescription: "Enable DNS forwarding to upstream DNS servers. When enabled, unmatched queries will be forwarded to a configured upstream resolver. Defaults to `false`, or the value set in the provider's `zone_defaults` block.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"regex": schema.BoolAttribute{
-				MarkdownDescription: "Use regular expression matching for the domain name. When enabled, the domain field can contain a regex pattern instead of a literal domain.",
-				Required:            true,
+				MarkdownDescription: "Use regular expression matching for the domain name. When enabled, the domain field can contain a regex pattern instead of a literal domain. Defaults to `false`, or the value set in the provider's `zone_defaults` block.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"master": schema.BoolAttribute{
 				Computed:            true,
 				MarkdownDescription: "Indicates if this is a master zone. Master zones have special privileges and cannot be modified via the API. This is set automatically during creation.",
 			},
-			"tags": schema.ListAttribute{
+			"tags": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "List of tags to organize and categorize zones. Tags can be used for filtering and grouping zones.",
+				MarkdownDescription: "Set of tags to organize and categorize zones. Tags can be used for filtering and grouping zones. Tags must not be empty, contain commas or start or end with whitespace.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(zoneTag()),
+				},
+			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "All tags of the zone: `tags` merged with the provider's `default_tags`.",
 			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
@@ -125,16 +144,18 @@ func (r *ZoneResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = data.Client
+	r.defaultTags = data.DefaultTags
+	r.defaults = data.ZoneDefaults
 }
 
 // CRUD methods are implemented in resource_zone_impl.go
@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -36,13 +40,11 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"domain": data.Domain.ValueString(),
 	})
 
-	// Convert tags list to comma-separated string
-	var tags []string
-	if !data.Tags.IsNull() {
-		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	// Convert the merged tags to a comma-separated string
+	tags, diags := tagsFromSet(ctx, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	tagsStr := strings.Join(tags, ",")
 
@@ -57,7 +59,7 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Tags:       tagsStr,
 	}
 
-	zone, err := r.client.CreateZone(createReq)
+	zone, err := r.client.CreateZone(ctx, createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating zone",
@@ -96,10 +98,10 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	defer cancel()
 
 	// Get zone from API
-	zone, err := r.client.GetZone(data.ID.ValueString())
+	zone, err := r.client.GetZone(ctx, data.ID.ValueString())
 	if err != nil {
 		// Check if this is a 404 - resource was deleted outside Terraform
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Zone not found, removing from state", map[string]any{
 				"id": data.ID.ValueString(),
 			})
@@ -125,16 +127,9 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.CreatedAt = types.StringValue(zone.CreatedAt)
 	data.UpdatedAt = types.StringValue(zone.UpdatedAt)
 
-	// Convert tags array to list
-	if len(zone.Tags) > 0 {
-		tagsValue, diags := types.ListValueFrom(ctx, types.StringType, zone.Tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.Tags = tagsValue
-	} else {
-		data.Tags = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(r.setTags(ctx, &data, zone.Tags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -159,13 +154,11 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	// Convert tags list to comma-separated string
-	var tags []string
-	if !data.Tags.IsNull() {
-		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	// Convert the merged tags to a comma-separated string
+	tags, diags := tagsFromSet(ctx, data.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	tagsStr := strings.Join(tags, ",")
 
@@ -185,7 +178,7 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Tags:       &tagsStr,
 	}
 
-	zone, err := r.client.UpdateZone(data.ID.ValueString(), updateReq)
+	zone, err := r.client.UpdateZone(ctx, data.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating zone",
@@ -206,16 +199,9 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.CreatedAt = types.StringValue(zone.CreatedAt)
 	data.UpdatedAt = types.StringValue(zone.UpdatedAt)
 
-	// Convert tags array to list
-	if len(zone.Tags) > 0 {
-		tagsValue, diags := types.ListValueFrom(ctx, types.StringType, zone.Tags)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		data.Tags = tagsValue
-	} else {
-		data.Tags = types.ListNull(types.StringType)
+	resp.Diagnostics.Append(r.setTags(ctx, &data, zone.Tags)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -241,7 +227,7 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	defer cancel()
 
 	// Delete zone via API
-	err := r.client.DeleteZoneWithContext(ctx, data.ID.ValueString())
+	err := r.client.DeleteZone(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting zone",
@@ -253,6 +239,97 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 // ImportState implements the resource import logic
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID from the import request
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Numeric import IDs are zone IDs and can be used as-is
+	if _, err := strconv.Atoi(req.ID); err == nil {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	// Otherwise the import ID is a domain name that has to be resolved to its ID
+	zone, err := r.client.GetZoneByDomain(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Zone not found",
+				fmt.Sprintf("No zone with domain %q exists. Import using either the numeric zone ID or the exact zone domain.", req.ID),
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error importing zone",
+			fmt.Sprintf("Could not look up zone by domain %q: %s", req.ID, err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(zone.ID))...)
+}
+
+// ModifyPlan fills in the defaults of the settings that are not configured
+// and merges the provider's default tags into tags_all
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(planDefaults(ctx, req.Config, &resp.Plan, map[string]attr.Value{
+		"active":     types.BoolValue(r.defaults.Active),
+		"catch_all":  types.BoolValue(r.defaults.CatchAll),
+		"forwarding": types.BoolValue(r.defaults.Forwarding),
+		"regex":      types.BoolValue(r.defaults.Regex),
+	}, r.client != nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The default tags are not known before the provider is configured, and
+	// the merge is not known while any tag is unknown
+	if r.client == nil || tags.IsUnknown() || slices.ContainsFunc(tags.Elements(), attr.Value.IsUnknown) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.SetUnknown(types.StringType))...)
+		return
+	}
+
+	planTags, diags := tagsFromSet(ctx, tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagsAll, diags := tagsSetValue(ctx, mergeTags(r.defaultTags, planTags))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+}
+
+// setTags sets tags_all to the tags stored on the server and tags to the
+// part of them that belongs to the zone rather than to the default tags,
+// using the tags in data as the previous state
+func (r *ZoneResource) setTags(ctx context.Context, data *ZoneResourceModel, serverTags []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	prevTags, d := tagsFromSet(ctx, data.Tags)
+	diags.Append(d...)
+	prevTagsAll, d := tagsFromSet(ctx, data.TagsAll)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Tags, d = tagsSetValue(ctx, resourceTags(serverTags, r.defaultTags, prevTags, prevTagsAll))
+	diags.Append(d...)
+	data.TagsAll, d = tagsSetValue(ctx, serverTags)
+	diags.Append(d...)
+
+	return diags
 }
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneNotificationResource{}
+var _ resource.ResourceWithImportState = &ZoneNotificationResource{}
+
+// NewZoneNotificationResource creates a new Zone Notification resource.
+func NewZoneNotificationResource() resource.Resource {
+	return &ZoneNotificationResource{}
+}
+
+// ZoneNotificationResource defines the resource implementation.
+type ZoneNotificationResource struct {
+	client *client.Client
+}
+
+// ZoneNotificationResourceModel describes the resource data model.
+type ZoneNotificationResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ZoneID       types.String   `tfsdk:"zone_id"`
+	ProviderName types.String   `tfsdk:"provider_name"`
+	TypeID       types.Int64    `tfsdk:"type_id"`
+	Enabled      types.Bool     `tfsdk:"enabled"`
+	Data         types.Dynamic  `tfsdk:"data"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneNotificationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_notification"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneNotificationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the subscription of a SnitchDNS zone to a notification provider such as email or webhook. SnitchDNS has no way to delete a subscription, so destroying this resource disables it instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the subscription in the format `zone_id:provider_name`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone the subscription belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the notification provider, e.g. `email` or `webhook`. Must be enabled globally on the SnitchDNS server.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type_id": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Internal ID of the notification provider type.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether notifications are sent for this zone. Defaults to `true`.",
+			},
+			"data": schema.DynamicAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Provider specific configuration. A list of addresses for `email` (e.g. `[\"soc@example.com\"]`), a string such as a URL or an object for other providers. If not set, the data stored on the server is kept.",
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneNotificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+// notificationErrorDiagnostic turns a notification API error into a diagnostic,
+// pointing at the attribute responsible for the known SnitchDNS error codes.
+func notificationErrorDiagnostic(summary, providerName string, err error) diag.Diagnostic {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case client.CodeInvalidNotificationType:
+			return diag.NewAttributeErrorDiagnostic(
+				path.Root("provider_name"),
+				"Unknown Notification Provider",
+				fmt.Sprintf("SnitchDNS does not know a notification provider named %q. Use the snitchdns_notification_providers data source to list the available providers.\n\nAPI error: %s", providerName, err),
+			)
+		case client.CodeInvalidSubscription:
+			return diag.NewAttributeErrorDiagnostic(
+				path.Root("data"),
+				"Invalid Notification Subscription",
+				fmt.Sprintf("SnitchDNS rejected the subscription data for provider %q. Email expects a list of addresses; other providers expect a string or an object.\n\nAPI error: %s", providerName, err),
+			)
+		case client.CodeNotificationDisabled:
+			return diag.NewAttributeErrorDiagnostic(
+				path.Root("provider_name"),
+				"Notification Provider Disabled",
+				fmt.Sprintf("The notification provider %q is disabled on this SnitchDNS server. An administrator has to enable it in the SnitchDNS settings before zones can subscribe to it.\n\nAPI error: %s", providerName, err),
+			)
+		}
+	}
+
+	return diag.NewErrorDiagnostic(summary, fmt.Sprintf("Notification provider %q: %s", providerName, err))
+}
+
+// CRUD methods are implemented in resource_zone_notification_impl.go
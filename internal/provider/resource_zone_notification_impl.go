@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"snitchdns-tf/internal/client"
+)
+
+// Create implements the resource create logic
+func (r *ZoneNotificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneNotificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Subscribing zone to notification provider", map[string]any{
+		"zone_id":       data.ZoneID.ValueString(),
+		"provider_name": data.ProviderName.ValueString(),
+	})
+
+	// Subscriptions always exist server-side; creating one means updating it
+	resp.Diagnostics.Append(r.update(ctx, &data, "Error creating zone notification")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneNotificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneNotificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	readTimeout, diags := data.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	subscription, err := r.client.GetNotification(ctx, data.ZoneID.ValueString(), data.ProviderName.ValueString())
+	if err != nil {
+		// Zone was deleted outside Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Zone notification not found, removing from state", map[string]any{
+				"zone_id":       data.ZoneID.ValueString(),
+				"provider_name": data.ProviderName.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.Append(notificationErrorDiagnostic("Error reading zone notification", data.ProviderName.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(setZoneNotificationState(&data, subscription)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneNotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneNotificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	updateTimeout, diags := data.Timeouts.Update(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.update(ctx, &data, "Error updating zone notification")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *ZoneNotificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneNotificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// The API has no DELETE for subscriptions, so disable it instead
+	enabled := false
+	_, err := r.client.UpdateNotification(ctx, data.ZoneID.ValueString(), data.ProviderName.ValueString(), client.UpdateNotificationRequest{
+		Enabled: &enabled,
+	})
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.Append(notificationErrorDiagnostic("Error disabling zone notification", data.ProviderName.ValueString(), err))
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneNotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: "zone_id:provider_name"
+	parts := strings.SplitN(req.ID, ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id:provider_name', got: %s", req.ID),
+		)
+		return
+	}
+
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid zone ID",
+			fmt.Sprintf("Zone ID must be numeric, got: %s", parts[0]),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("provider_name"), parts[1])...)
+}
+
+// update sends the planned subscription to the API and maps the response back into data.
+func (r *ZoneNotificationResource) update(ctx context.Context, data *ZoneNotificationResourceModel, summary string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// Data that is not configured is unknown until the server reports it and
+	// left alone on the server
+	var payload interface{}
+	if !data.Data.IsUnknown() {
+		var err error
+		payload, err = dynamicToJSON(data.Data)
+		if err != nil {
+			diags.AddAttributeError(path.Root("data"), "Invalid Notification Data", err.Error())
+			return diags
+		}
+	}
+
+	enabled := data.Enabled.ValueBool()
+	subscription, err := r.client.UpdateNotification(ctx, data.ZoneID.ValueString(), data.ProviderName.ValueString(), client.UpdateNotificationRequest{
+		Enabled: &enabled,
+		Data:    payload,
+	})
+	if err != nil {
+		diags.Append(notificationErrorDiagnostic(summary, data.ProviderName.ValueString(), err))
+		return diags
+	}
+
+	diags.Append(setZoneNotificationState(data, subscription)...)
+	return diags
+}
+
+// setZoneNotificationState maps an API subscription into the resource model.
+// The current data value is kept when it encodes to the same JSON as the API
+// response, so that the HCL type the user chose (tuple, list, object, ...) is
+// preserved. Unknown data, i.e. data that is not configured, takes the value
+// stored on the server.
+func setZoneNotificationState(data *ZoneNotificationResourceModel, subscription *client.NotificationSubscription) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.ID = types.StringValue(data.ZoneID.ValueString() + ":" + data.ProviderName.ValueString())
+	data.TypeID = types.Int64Value(int64(subscription.TypeID))
+	data.Enabled = types.BoolValue(subscription.Enabled)
+
+	current, err := dynamicToJSON(data.Data)
+	switch {
+	case err == nil && jsonEqual(current, subscription.Data):
+		// Unchanged, keep the configured type
+	case (data.Data.IsNull() || data.Data.IsUnknown()) && jsonEmpty(subscription.Data):
+		// Nothing configured and nothing stored
+		data.Data = types.DynamicNull()
+	default:
+		value, err := jsonToDynamic(subscription.Data)
+		if err != nil {
+			diags.AddAttributeError(path.Root("data"), "Invalid Notification Data", fmt.Sprintf("Could not decode subscription data returned by the API: %s", err))
+			return diags
+		}
+		data.Data = value
+	}
+
+	return diags
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"snitchdns-tf/internal/client"
+	"snitchdns-tf/internal/testcontainer"
+)
+
+// TestAccZoneNotificationResource_Errors tests that notification API errors map to clear diagnostics
+func TestAccZoneNotificationResource_Errors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			// Unknown providers are reported against provider_name
+			{
+				Config:      testAccZoneNotificationResourceConfig(container, "does-not-exist", `"https://hooks.example.com/canary"`),
+				ExpectError: regexp.MustCompile(`Unknown Notification Provider|Notification Provider Disabled`),
+			},
+			// Email is disabled on a fresh SnitchDNS instance
+			{
+				Config:      testAccZoneNotificationResourceConfig(container, "email", `["soc@example.com"]`),
+				ExpectError: regexp.MustCompile(`Notification Provider Disabled`),
+			},
+		},
+	})
+}
+
+// TestZoneNotificationState tests that the configured data type is kept when the API returns equal data
+func TestZoneNotificationState(t *testing.T) {
+	emails, _ := types.TupleValue(
+		[]attr.Type{types.StringType, types.StringType},
+		[]attr.Value{types.StringValue("soc@example.com"), types.StringValue("cert@example.com")},
+	)
+
+	tests := []struct {
+		name     string
+		current  types.Dynamic
+		response string
+		wantSame bool
+		wantNull bool
+		wantJSON string
+	}{
+		{"email list unchanged", types.DynamicValue(emails), `["soc@example.com", "cert@example.com"]`, true, false, ""},
+		{"webhook string unchanged", types.DynamicValue(types.StringValue("https://hooks.example.com/x")), `"https://hooks.example.com/x"`, true, false, ""},
+		{"null stays null for empty data", types.DynamicNull(), `[]`, false, true, ""},
+		{"drift replaces value", types.DynamicValue(emails), `["attacker@example.com"]`, false, false, `["attacker@example.com"]`},
+		{"object from API", types.DynamicNull(), `{"url": "https://hooks.example.com/y", "retries": 3}`, false, false, `{"retries":3,"url":"https://hooks.example.com/y"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ZoneNotificationResourceModel{
+				ZoneID:       types.StringValue("7"),
+				ProviderName: types.StringValue("email"),
+				Data:         tt.current,
+			}
+
+			diags := setZoneNotificationState(&data, &client.NotificationSubscription{
+				TypeID:  1,
+				Enabled: true,
+				Data:    json.RawMessage(tt.response),
+			})
+			if diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+
+			if data.ID.ValueString() != "7:email" {
+				t.Errorf("Unexpected ID %s", data.ID.ValueString())
+			}
+			if tt.wantSame && !data.Data.Equal(tt.current) {
+				t.Errorf("Expected configured value to be kept, got %s", data.Data)
+			}
+			if tt.wantNull && !data.Data.IsNull() {
+				t.Errorf("Expected null data, got %s", data.Data)
+			}
+			if tt.wantJSON != "" {
+				value, err := dynamicToJSON(data.Data)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				encoded, _ := json.Marshal(value)
+				if string(encoded) != tt.wantJSON {
+					t.Errorf("Expected %s, got %s", tt.wantJSON, encoded)
+				}
+			}
+		})
+	}
+}
+
+// TestZoneNotificationCreateKeepsServerData tests that data which is not configured is left alone and read from the server
+func TestZoneNotificationCreateKeepsServerData(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		stored   string
+		wantNull bool
+		wantJSON string
+	}{
+		{"stored data", `["soc@example.com"]`, false, `["soc@example.com"]`},
+		{"nothing stored", `[]`, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent map[string]json.RawMessage
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/zones/7/notifications/email" {
+					t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&sent)
+				w.Write([]byte(`{"zone_id": 7, "type_id": 1, "type": "email", "enabled": true, "data": ` + tt.stored + `}`))
+			}))
+			defer server.Close()
+
+			r := &ZoneNotificationResource{client: client.NewClient(server.URL, "test-key")}
+			schemaResp := fwresource.SchemaResponse{}
+			r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			// Terraform plans data that is not configured as unknown
+			raw := map[string]tftypes.Value{}
+			for name, typ := range objectType.AttributeTypes {
+				raw[name] = tftypes.NewValue(typ, tftypes.UnknownValue)
+			}
+			raw["zone_id"] = tftypes.NewValue(tftypes.String, "7")
+			raw["provider_name"] = tftypes.NewValue(tftypes.String, "email")
+			raw["enabled"] = tftypes.NewValue(tftypes.Bool, true)
+			raw["timeouts"] = tftypes.NewValue(objectType.AttributeTypes["timeouts"], nil)
+
+			req := fwresource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, raw)},
+			}
+			resp := &fwresource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+			r.Create(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if _, ok := sent["data"]; ok {
+				t.Errorf("Expected no data to be sent, got %s", sent["data"])
+			}
+
+			var data ZoneNotificationResourceModel
+			if diags := resp.State.Get(ctx, &data); diags.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", diags)
+			}
+			if tt.wantNull && !data.Data.IsNull() {
+				t.Errorf("Expected null data, got %s", data.Data)
+			}
+			if tt.wantJSON != "" {
+				value, err := dynamicToJSON(data.Data)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				encoded, _ := json.Marshal(value)
+				if string(encoded) != tt.wantJSON {
+					t.Errorf("Expected %s, got %s", tt.wantJSON, encoded)
+				}
+			}
+		})
+	}
+}
+
+// testAccZoneNotificationResourceConfig generates HCL configuration for notification testing
+func testAccZoneNotificationResourceConfig(container *testcontainer.SnitchDNSContainer, providerName, data string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain     = "notification-test.example.com"
+  active     = true
+  catch_all  = false
+  forwarding = false
+  regex      = false
+}
+
+resource "snitchdns_zone_notification" "test" {
+  zone_id       = snitchdns_zone.test.id
+  provider_name = %[3]q
+  data          = %[4]s
+}
+`, container.GetAPIEndpoint(), container.APIKey, providerName, data)
+}
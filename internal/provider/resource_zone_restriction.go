@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneRestrictionResource{}
+var _ resource.ResourceWithImportState = &ZoneRestrictionResource{}
+
+// NewZoneRestrictionResource creates a new Zone Restriction resource.
+func NewZoneRestrictionResource() resource.Resource {
+	return &ZoneRestrictionResource{}
+}
+
+// ZoneRestrictionResource defines the resource implementation.
+type ZoneRestrictionResource struct {
+	client *client.Client
+}
+
+// ZoneRestrictionResourceModel describes the resource data model.
+type ZoneRestrictionResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	ZoneID    types.String   `tfsdk:"zone_id"`
+	Type      types.String   `tfsdk:"type"`
+	Enabled   types.Bool     `tfsdk:"enabled"`
+	IPOrRange types.String   `tfsdk:"ip_or_range"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneRestrictionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_restriction"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneRestrictionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a single IP restriction of a SnitchDNS zone. Restrictions allow or block DNS queries to the zone based on the source IP address or range of the client.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier for the restriction. Assigned by the API upon creation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone this restriction belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether matching clients are allowed (`allow`) or blocked (`block`).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.RestrictionTypeAllow, client.RestrictionTypeBlock),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the restriction is enforced. Defaults to `true`.",
+			},
+			"ip_or_range": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Single IP address (e.g., `192.168.0.10`) or CIDR range (e.g., `192.168.0.0/24`) the restriction applies to. IPv4 and IPv6 are supported.",
+				Validators: []validator.String{
+					ipOrCIDR(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneRestrictionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+// CRUD methods are implemented in resource_zone_restriction_impl.go
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"snitchdns-tf/internal/client"
+)
+
+// Create implements the resource create logic
+func (r *ZoneRestrictionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating zone restriction", map[string]any{
+		"zone_id":     data.ZoneID.ValueString(),
+		"ip_or_range": data.IPOrRange.ValueString(),
+	})
+
+	createReq := client.CreateRestrictionRequest{
+		Type:      data.Type.ValueString(),
+		Enabled:   data.Enabled.ValueBool(),
+		IPOrRange: data.IPOrRange.ValueString(),
+	}
+
+	restriction, err := r.client.CreateRestriction(ctx, data.ZoneID.ValueString(), createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating zone restriction",
+			fmt.Sprintf("Could not create restriction in zone %s: %s", data.ZoneID.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(restriction.ID))
+	data.Type = types.StringValue(restriction.Type)
+	data.Enabled = types.BoolValue(restriction.Enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneRestrictionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	readTimeout, diags := data.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	restriction, err := r.client.GetRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil {
+		// Restriction (or its zone) was deleted outside Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Zone restriction not found, removing from state", map[string]any{
+				"zone_id":        data.ZoneID.ValueString(),
+				"restriction_id": data.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading zone restriction",
+			fmt.Sprintf("Could not read restriction ID %s in zone %s: %s",
+				data.ID.ValueString(), data.ZoneID.ValueString(), err),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(restriction.ID))
+	data.Type = types.StringValue(restriction.Type)
+	data.Enabled = types.BoolValue(restriction.Enabled)
+	data.IPOrRange = types.StringValue(restriction.IP)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneRestrictionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	updateTimeout, diags := data.Timeouts.Update(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	typ := data.Type.ValueString()
+	enabled := data.Enabled.ValueBool()
+	ipOrRange := data.IPOrRange.ValueString()
+
+	updateReq := client.UpdateRestrictionRequest{
+		Type:      &typ,
+		Enabled:   &enabled,
+		IPOrRange: &ipOrRange,
+	}
+
+	restriction, err := r.client.UpdateRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString(), updateReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone restriction",
+			fmt.Sprintf("Could not update restriction ID %s in zone %s: %s",
+				data.ID.ValueString(), data.ZoneID.ValueString(), err),
+		)
+		return
+	}
+
+	data.Type = types.StringValue(restriction.Type)
+	data.Enabled = types.BoolValue(restriction.Enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *ZoneRestrictionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneRestrictionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteRestriction(ctx, data.ZoneID.ValueString(), data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Error deleting zone restriction",
+			fmt.Sprintf("Could not delete restriction ID %s in zone %s: %s",
+				data.ID.ValueString(), data.ZoneID.ValueString(), err),
+		)
+		return
+	}
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneRestrictionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: "zone_id:restriction_id"
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"Invalid import ID format",
+			fmt.Sprintf("Expected import ID format 'zone_id:restriction_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	zoneID := parts[0]
+	restrictionID := parts[1]
+
+	// Validate they are numeric
+	if _, err := strconv.Atoi(zoneID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid zone ID",
+			fmt.Sprintf("Zone ID must be numeric, got: %s", zoneID),
+		)
+		return
+	}
+	if _, err := strconv.Atoi(restrictionID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid restriction ID",
+			fmt.Sprintf("Restriction ID must be numeric, got: %s", restrictionID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), zoneID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), restrictionID)...)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"snitchdns-tf/internal/testcontainer"
+)
+
+// TestAccZoneRestrictionResource tests the Zone Restriction resource CRUD operations
+func TestAccZoneRestrictionResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Start SnitchDNS test container
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			// Invalid ranges are rejected during plan
+			{
+				Config:      testAccZoneRestrictionResourceConfig(container, "restriction-test.example.com", "allow", "10.0.0.0/33", true),
+				ExpectError: regexp.MustCompile(`Invalid IP Address or CIDR Range`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccZoneRestrictionResourceConfig(container, "restriction-test.example.com", "allow", "10.0.0.0/8", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("snitchdns_zone_restriction.test", "id"),
+					resource.TestCheckResourceAttrPair("snitchdns_zone_restriction.test", "zone_id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "type", "allow"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "ip_or_range", "10.0.0.0/8"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "snitchdns_zone_restriction.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccZoneRestrictionImportStateIdFunc,
+				ImportStateVerify: true,
+			},
+			// Update type and enabled flag
+			{
+				Config: testAccZoneRestrictionResourceConfig(container, "restriction-test.example.com", "block", "10.0.0.0/8", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "type", "block"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restriction.test", "enabled", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccZoneRestrictionImportStateIdFunc returns the import ID in format "zone_id:restriction_id"
+func testAccZoneRestrictionImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["snitchdns_zone_restriction.test"]
+	if !ok {
+		return "", fmt.Errorf("Resource not found")
+	}
+
+	return fmt.Sprintf("%s:%s", rs.Primary.Attributes["zone_id"], rs.Primary.ID), nil
+}
+
+// testAccZoneRestrictionResourceConfig generates HCL configuration for restriction testing
+func testAccZoneRestrictionResourceConfig(container *testcontainer.SnitchDNSContainer, domain, typ, ipOrRange string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain     = %[3]q
+  active     = true
+  catch_all  = false
+  forwarding = false
+  regex      = false
+}
+
+resource "snitchdns_zone_restriction" "test" {
+  zone_id     = snitchdns_zone.test.id
+  type        = %[4]q
+  ip_or_range = %[5]q
+  enabled     = %[6]t
+}
+`, container.GetAPIEndpoint(), container.APIKey, domain, typ, ipOrRange, enabled)
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"snitchdns-tf/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneRestrictionsResource{}
+var _ resource.ResourceWithImportState = &ZoneRestrictionsResource{}
+var _ resource.ResourceWithValidateConfig = &ZoneRestrictionsResource{}
+
+// NewZoneRestrictionsResource creates a new authoritative Zone Restrictions resource.
+func NewZoneRestrictionsResource() resource.Resource {
+	return &ZoneRestrictionsResource{}
+}
+
+// ZoneRestrictionsResource defines the resource implementation.
+type ZoneRestrictionsResource struct {
+	client *client.Client
+}
+
+// ZoneRestrictionsResourceModel describes the resource data model.
+type ZoneRestrictionsResourceModel struct {
+	ID           types.String   `tfsdk:"id"`
+	ZoneID       types.String   `tfsdk:"zone_id"`
+	Restrictions types.Set      `tfsdk:"restrictions"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// zoneRestrictionEntryModel describes a single element of the restrictions set.
+type zoneRestrictionEntryModel struct {
+	Type      types.String `tfsdk:"type"`
+	IPOrRange types.String `tfsdk:"ip_or_range"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+}
+
+// zoneRestrictionEntryAttrTypes are the attribute types of a restrictions set element.
+var zoneRestrictionEntryAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"ip_or_range": types.StringType,
+	"enabled":     types.BoolType,
+}
+
+// Metadata sets the resource type name.
+func (r *ZoneRestrictionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_restrictions"
+}
+
+// Schema defines the resource schema.
+func (r *ZoneRestrictionsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manages the complete IP allow/block list of a SnitchDNS zone. Every apply converges the zone's restrictions to exactly the configured set: missing entries are created, changed entries are updated and entries not in the configuration are deleted.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the resource. Equal to `zone_id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the zone whose restrictions are managed.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"restrictions": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The complete set of restrictions of the zone. An empty set removes every restriction. Each `ip_or_range` may only appear once.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Whether matching clients are allowed (`allow`) or blocked (`block`).",
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.RestrictionTypeAllow, client.RestrictionTypeBlock),
+							},
+						},
+						"ip_or_range": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Single IP address or CIDR range the restriction applies to.",
+							Validators: []validator.String{
+								ipOrCIDR(),
+							},
+						},
+						"enabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Whether the restriction is enforced. Defaults to `true`.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the resource.
+func (r *ZoneRestrictionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+// ValidateConfig rejects duplicate ranges and warns about overlapping allow and block ranges.
+func (r *ZoneRestrictionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var restrictions types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("restrictions"), &restrictions)...)
+	if resp.Diagnostics.HasError() || restrictions.IsNull() || restrictions.IsUnknown() {
+		return
+	}
+
+	var entries []zoneRestrictionEntryModel
+	resp.Diagnostics.Append(restrictions.ElementsAs(ctx, &entries, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	var allow, block []string
+	for _, entry := range entries {
+		if entry.IPOrRange.IsUnknown() || entry.IPOrRange.IsNull() {
+			continue
+		}
+
+		ipOrRange := entry.IPOrRange.ValueString()
+		key := restrictionKey(ipOrRange)
+		if seen[key] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("restrictions"),
+				"Duplicate Restriction",
+				fmt.Sprintf("The range %q is listed more than once. Each IP address or range may only appear once per zone.", ipOrRange),
+			)
+			continue
+		}
+		seen[key] = true
+
+		switch entry.Type.ValueString() {
+		case client.RestrictionTypeAllow:
+			allow = append(allow, ipOrRange)
+		case client.RestrictionTypeBlock:
+			block = append(block, ipOrRange)
+		}
+	}
+
+	for _, a := range allow {
+		allowPrefix, err := parseIPOrCIDR(a)
+		if err != nil {
+			continue
+		}
+		for _, b := range block {
+			blockPrefix, err := parseIPOrCIDR(b)
+			if err != nil {
+				continue
+			}
+			if allowPrefix.Overlaps(blockPrefix) {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("restrictions"),
+					"Overlapping Allow and Block Ranges",
+					fmt.Sprintf("The allowed range %q overlaps the blocked range %q. Which one wins for addresses in both ranges is decided by SnitchDNS, not by this configuration.", a, b),
+				)
+			}
+		}
+	}
+}
+
+// restrictionKey returns the key used to match configured and existing
+// restrictions. Parseable values are canonicalised so that "10.0.0.1" and
+// "10.0.0.1/32" refer to the same entry.
+func restrictionKey(ipOrRange string) string {
+	prefix, err := parseIPOrCIDR(ipOrRange)
+	if err != nil {
+		return ipOrRange
+	}
+	return prefix.String()
+}
+
+// CRUD methods are implemented in resource_zone_restrictions_impl.go
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"snitchdns-tf/internal/client"
+)
+
+// Create implements the resource create logic
+func (r *ZoneRestrictionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.converge(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read implements the resource read logic
+func (r *ZoneRestrictionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	readTimeout, diags := data.Timeouts.Read(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	restrictions, err := r.client.ListRestrictions(ctx, data.ZoneID.ValueString())
+	if err != nil {
+		// Zone was deleted outside Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Zone not found, removing restrictions from state", map[string]any{
+				"zone_id": data.ZoneID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading zone restrictions",
+			fmt.Sprintf("Could not read restrictions of zone %s: %s", data.ZoneID.ValueString(), err),
+		)
+		return
+	}
+
+	// Keep the configured spelling of ranges the server stores in canonical form
+	configured := make(map[string]string)
+	if !data.Restrictions.IsNull() && !data.Restrictions.IsUnknown() {
+		var prior []zoneRestrictionEntryModel
+		resp.Diagnostics.Append(data.Restrictions.ElementsAs(ctx, &prior, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, entry := range prior {
+			configured[restrictionKey(entry.IPOrRange.ValueString())] = entry.IPOrRange.ValueString()
+		}
+	}
+
+	// The server accepts several restrictions for the same range, which
+	// cannot all be stored in the set. A duplicated range is left out of the
+	// state, so that the next plan adds it again and converge keeps one of
+	// the restrictions and deletes the others.
+	counts := make(map[string]int)
+	for _, restriction := range restrictions {
+		counts[restrictionKey(restriction.IP)]++
+	}
+
+	entries := make([]zoneRestrictionEntryModel, 0, len(restrictions))
+	warned := make(map[string]bool)
+	for _, restriction := range restrictions {
+		key := restrictionKey(restriction.IP)
+		if counts[key] > 1 {
+			if !warned[key] {
+				warned[key] = true
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("restrictions"),
+					"Duplicate Zone Restrictions",
+					fmt.Sprintf("Zone %s has %d restrictions for %s. The next apply keeps one of them and deletes the others.", data.ZoneID.ValueString(), counts[key], key),
+				)
+			}
+			continue
+		}
+
+		ipOrRange := restriction.IP
+		if spelling, ok := configured[key]; ok {
+			ipOrRange = spelling
+		}
+		entries = append(entries, zoneRestrictionEntryModel{
+			Type:      types.StringValue(restriction.Type),
+			IPOrRange: types.StringValue(ipOrRange),
+			Enabled:   types.BoolValue(restriction.Enabled),
+		})
+	}
+
+	restrictionsValue, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: zoneRestrictionEntryAttrTypes}, entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ID = data.ZoneID
+	data.Restrictions = restrictionsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update implements the resource update logic
+func (r *ZoneRestrictionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ZoneRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	updateTimeout, diags := data.Timeouts.Update(ctx, 2*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.converge(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.ZoneID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete implements the resource delete logic
+func (r *ZoneRestrictionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneRestrictionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create timeout context
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, 3*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// The resource owns the whole list, so destroying it clears the list
+	data.Restrictions = types.SetValueMust(types.ObjectType{AttrTypes: zoneRestrictionEntryAttrTypes}, nil)
+	resp.Diagnostics.Append(r.converge(ctx, &data)...)
+}
+
+// ImportState implements the resource import logic
+func (r *ZoneRestrictionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID is the numeric zone ID
+	if _, err := strconv.Atoi(req.ID); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid zone ID",
+			fmt.Sprintf("Zone ID must be numeric, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// converge makes the zone's restrictions on the server match data.Restrictions
+// exactly. Existing entries are matched by their range: matching entries are
+// updated in place, missing ones created and all others deleted.
+func (r *ZoneRestrictionsResource) converge(ctx context.Context, data *ZoneRestrictionsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	zoneID := data.ZoneID.ValueString()
+
+	var desired []zoneRestrictionEntryModel
+	diags.Append(data.Restrictions.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	existing, err := r.client.ListRestrictions(ctx, zoneID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) && len(desired) == 0 {
+			// Nothing to clear in a zone that no longer exists
+			return diags
+		}
+		diags.AddError(
+			"Error reading zone restrictions",
+			fmt.Sprintf("Could not read restrictions of zone %s: %s", zoneID, err),
+		)
+		return diags
+	}
+
+	byKey := make(map[string][]client.Restriction)
+	for _, restriction := range existing {
+		key := restrictionKey(restriction.IP)
+		byKey[key] = append(byKey[key], restriction)
+	}
+
+	for _, entry := range desired {
+		key := restrictionKey(entry.IPOrRange.ValueString())
+		typ := entry.Type.ValueString()
+		enabled := entry.Enabled.ValueBool()
+
+		matches := byKey[key]
+		if len(matches) == 0 {
+			tflog.Debug(ctx, "Creating zone restriction", map[string]any{
+				"zone_id":     zoneID,
+				"ip_or_range": entry.IPOrRange.ValueString(),
+			})
+			_, err := r.client.CreateRestriction(ctx, zoneID, client.CreateRestrictionRequest{
+				Type:      typ,
+				Enabled:   enabled,
+				IPOrRange: entry.IPOrRange.ValueString(),
+			})
+			if err != nil {
+				diags.AddError(
+					"Error creating zone restriction",
+					fmt.Sprintf("Could not create restriction %s in zone %s: %s", entry.IPOrRange.ValueString(), zoneID, err),
+				)
+				return diags
+			}
+			continue
+		}
+
+		// Keep the first match; any duplicates are deleted below
+		current := matches[0]
+		byKey[key] = matches[1:]
+		if current.Type == typ && current.Enabled == enabled {
+			continue
+		}
+
+		tflog.Debug(ctx, "Updating zone restriction", map[string]any{
+			"zone_id":        zoneID,
+			"restriction_id": current.ID,
+		})
+		_, err := r.client.UpdateRestriction(ctx, zoneID, strconv.Itoa(current.ID), client.UpdateRestrictionRequest{
+			Type:    &typ,
+			Enabled: &enabled,
+		})
+		if err != nil {
+			diags.AddError(
+				"Error updating zone restriction",
+				fmt.Sprintf("Could not update restriction ID %d in zone %s: %s", current.ID, zoneID, err),
+			)
+			return diags
+		}
+	}
+
+	for _, unmanaged := range byKey {
+		for _, restriction := range unmanaged {
+			tflog.Debug(ctx, "Deleting unmanaged zone restriction", map[string]any{
+				"zone_id":        zoneID,
+				"restriction_id": restriction.ID,
+				"ip_or_range":    restriction.IP,
+			})
+			err := r.client.DeleteRestriction(ctx, zoneID, strconv.Itoa(restriction.ID))
+			if err != nil && !errors.Is(err, client.ErrNotFound) {
+				diags.AddError(
+					"Error deleting zone restriction",
+					fmt.Sprintf("Could not delete restriction ID %d in zone %s: %s", restriction.ID, zoneID, err),
+				)
+				return diags
+			}
+		}
+	}
+
+	return diags
+}
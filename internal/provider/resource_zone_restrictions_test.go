@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"snitchdns-tf/internal/client"
+	"snitchdns-tf/internal/testcontainer"
+)
+
+// TestAccZoneRestrictionsResource tests that the zone restriction list is converged authoritatively
+func TestAccZoneRestrictionsResource(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	// Start SnitchDNS test container
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}()
+
+	apiClient := client.NewClient(container.GetAPIEndpoint(), container.APIKey)
+	var zoneID string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccZoneRestrictionsResourceConfig(container, `
+    { type = "allow", ip_or_range = "10.0.0.0/8" },
+    { type = "block", ip_or_range = "192.0.2.1" },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("snitchdns_zone_restrictions.test", "id", "snitchdns_zone.test", "id"),
+					resource.TestCheckResourceAttr("snitchdns_zone_restrictions.test", "restrictions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("snitchdns_zone_restrictions.test", "restrictions.*", map[string]string{
+						"type":        "allow",
+						"ip_or_range": "10.0.0.0/8",
+						"enabled":     "true",
+					}),
+					testAccCaptureAttr("snitchdns_zone.test", "id", &zoneID),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "snitchdns_zone_restrictions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Entries created outside Terraform show up as drift
+			{
+				PreConfig: func() {
+					_, err := apiClient.CreateRestriction(ctx, zoneID, client.CreateRestrictionRequest{
+						Type:      client.RestrictionTypeBlock,
+						Enabled:   true,
+						IPOrRange: "198.51.100.0/24",
+					})
+					if err != nil {
+						t.Fatalf("Failed to create out-of-band restriction: %v", err)
+					}
+				},
+				Config: testAccZoneRestrictionsResourceConfig(container, `
+    { type = "allow", ip_or_range = "10.0.0.0/8" },
+    { type = "block", ip_or_range = "192.0.2.1" },`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// Converge: unmanaged entry deleted, changed entry updated, new entry created
+			{
+				Config: testAccZoneRestrictionsResourceConfig(container, `
+    { type = "allow", ip_or_range = "10.0.0.0/8", enabled = false },
+    { type = "allow", ip_or_range = "2001:db8::/32" },`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone_restrictions.test", "restrictions.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("snitchdns_zone_restrictions.test", "restrictions.*", map[string]string{
+						"ip_or_range": "10.0.0.0/8",
+						"enabled":     "false",
+					}),
+					testAccCheckZoneRestrictionCount(ctx, apiClient, &zoneID, 2),
+				),
+			},
+			// Overlapping allow and block ranges only warn
+			{
+				Config: testAccZoneRestrictionsResourceConfig(container, `
+    { type = "allow", ip_or_range = "10.0.0.0/8" },
+    { type = "block", ip_or_range = "10.1.0.0/16" },`),
+				Check: testAccCheckZoneRestrictionCount(ctx, apiClient, &zoneID, 2),
+			},
+			// Duplicate ranges are rejected
+			{
+				Config: testAccZoneRestrictionsResourceConfig(container, `
+    { type = "allow", ip_or_range = "10.0.0.1" },
+    { type = "block", ip_or_range = "10.0.0.1/32" },`),
+				ExpectError: regexp.MustCompile(`Duplicate Restriction`),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// TestZoneRestrictionsValidateConfig tests the overlap warning and duplicate error
+func TestZoneRestrictionsValidateConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		entries      [][2]string
+		wantWarnings int
+		wantErrors   int
+	}{
+		{"disjoint", [][2]string{{"allow", "10.0.0.0/8"}, {"block", "192.0.2.0/24"}}, 0, 0},
+		{"overlap", [][2]string{{"allow", "10.0.0.0/8"}, {"block", "10.1.2.3"}}, 1, 0},
+		{"same type overlap", [][2]string{{"block", "10.0.0.0/8"}, {"block", "10.1.0.0/16"}}, 0, 0},
+		{"duplicate", [][2]string{{"allow", "10.0.0.1"}, {"block", "10.0.0.1/32"}}, 0, 1},
+	}
+
+	r := &ZoneRestrictionsResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(context.Background()).(tftypes.Object)
+	entryType := configType.AttributeTypes["restrictions"].(tftypes.Set).ElementType.(tftypes.Object)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var elements []tftypes.Value
+			for _, e := range tt.entries {
+				elements = append(elements, tftypes.NewValue(entryType, map[string]tftypes.Value{
+					"type":        tftypes.NewValue(tftypes.String, e[0]),
+					"ip_or_range": tftypes.NewValue(tftypes.String, e[1]),
+					"enabled":     tftypes.NewValue(tftypes.Bool, nil),
+				}))
+			}
+
+			config := testObject(configType, map[string]tftypes.Value{
+				"restrictions": tftypes.NewValue(tftypes.Set{ElementType: entryType}, elements),
+			})
+
+			req := fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config},
+			}
+			resp := &fwresource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.WarningsCount(); got != tt.wantWarnings {
+				t.Errorf("Expected %d warnings, got %d: %v", tt.wantWarnings, got, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("Expected %d errors, got %d: %v", tt.wantErrors, got, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestZoneRestrictionsReadDuplicates tests that restrictions the server holds
+// twice for the same range are left out of the state and removed by the next apply
+func TestZoneRestrictionsReadDuplicates(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	restrictions := map[int]string{
+		1: `{"id": 1, "zone_id": 1, "ip": "10.0.0.1", "type": "allow", "enabled": true}`,
+		2: `{"id": 2, "zone_id": 1, "ip": "10.0.0.1/32", "type": "allow", "enabled": true}`,
+		3: `{"id": 3, "zone_id": 1, "ip": "10.0.0.2", "type": "block", "enabled": true}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/zones/1/restrictions":
+			var list []string
+			for id := 1; id <= 3; id++ {
+				if restriction, ok := restrictions[id]; ok {
+					list = append(list, restriction)
+				}
+			}
+			w.Write([]byte("[" + strings.Join(list, ",") + "]"))
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/zones/1/restrictions/"):
+			var id int
+			fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/zones/1/restrictions/"), "%d", &id)
+			delete(restrictions, id)
+			w.Write([]byte(`{"success": true}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	r := &ZoneRestrictionsResource{client: client.NewClient(server.URL, "test-key")}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	entryType := objectType.AttributeTypes["restrictions"].(tftypes.Set).ElementType.(tftypes.Object)
+	entry := func(typ, ipOrRange string) tftypes.Value {
+		return tftypes.NewValue(entryType, map[string]tftypes.Value{
+			"type":        tftypes.NewValue(tftypes.String, typ),
+			"ip_or_range": tftypes.NewValue(tftypes.String, ipOrRange),
+			"enabled":     tftypes.NewValue(tftypes.Bool, true),
+		})
+	}
+	configured := testObject(objectType, map[string]tftypes.Value{
+		"id":      tftypes.NewValue(tftypes.String, "1"),
+		"zone_id": tftypes.NewValue(tftypes.String, "1"),
+		"restrictions": tftypes.NewValue(tftypes.Set{ElementType: entryType}, []tftypes.Value{
+			entry("allow", "10.0.0.1"), entry("block", "10.0.0.2"),
+		}),
+	})
+
+	readResp := &fwresource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: configured}}
+	r.Read(ctx, fwresource.ReadRequest{State: readResp.State}, readResp)
+	if readResp.Diagnostics.HasError() || readResp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("Expected a single warning, got: %v", readResp.Diagnostics)
+	}
+	var data ZoneRestrictionsResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &data)...)
+	var entries []zoneRestrictionEntryModel
+	readResp.Diagnostics.Append(data.Restrictions.ElementsAs(ctx, &entries, false)...)
+	if len(entries) != 1 || entries[0].IPOrRange.ValueString() != "10.0.0.2" {
+		t.Errorf("Expected only the unique restriction in state, got %v", entries)
+	}
+
+	updateResp := &fwresource.UpdateResponse{State: readResp.State}
+	r.Update(ctx, fwresource.UpdateRequest{
+		State: readResp.State,
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: configured},
+	}, updateResp)
+	if updateResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", updateResp.Diagnostics)
+	}
+	if _, ok := restrictions[1]; !ok || len(restrictions) != 2 {
+		t.Errorf("Expected the first restriction to be kept and its duplicate deleted, got %v", restrictions)
+	}
+}
+
+// testAccCaptureAttr stores an attribute value of a resource for later steps
+func testAccCaptureAttr(name, key string, target *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Resource not found: %s", name)
+		}
+		*target = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccCheckZoneRestrictionCount verifies the number of restrictions stored on the server
+func testAccCheckZoneRestrictionCount(ctx context.Context, apiClient *client.Client, zoneID *string, want int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		restrictions, err := apiClient.ListRestrictions(ctx, *zoneID)
+		if err != nil {
+			return err
+		}
+		if len(restrictions) != want {
+			return fmt.Errorf("Expected %d restrictions on the server, got %d", want, len(restrictions))
+		}
+		return nil
+	}
+}
+
+// testAccZoneRestrictionsResourceConfig generates HCL configuration for restriction list testing
+func testAccZoneRestrictionsResourceConfig(container *testcontainer.SnitchDNSContainer, restrictions string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url = %[1]q
+  api_key = %[2]q
+}
+
+resource "snitchdns_zone" "test" {
+  domain     = "restrictions-test.example.com"
+  active     = true
+  catch_all  = false
+  forwarding = false
+  regex      = false
+}
+
+resource "snitchdns_zone_restrictions" "test" {
+  zone_id = snitchdns_zone.test.id
+
+  restrictions = [%[3]s
+  ]
+}
+`, container.GetAPIEndpoint(), container.APIKey, strings.TrimSuffix(restrictions, ","))
+}
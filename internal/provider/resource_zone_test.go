@@ -3,10 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"snitchdns-tf/internal/testcontainer"
 )
 
@@ -57,6 +64,13 @@ func TestAccZoneResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by domain name
+			{
+				ResourceName:      "snitchdns_zone.test",
+				ImportState:       true,
+				ImportStateId:     "test.example.com",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccZoneResourceConfig(container, "test.example.com", false, true),
@@ -95,10 +109,66 @@ func TestAccZoneResource_WithTags(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("snitchdns_zone.test", "domain", "tagged.example.com"),
 					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.#", "2"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.0", "production"),
-					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.1", "web"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags.*", "production"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags.*", "web"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccZoneResource_DefaultTags tests merging the provider's default_tags into zones
+func TestAccZoneResource_DefaultTags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	ctx := context.Background()
+
+	container, err := testcontainer.NewSnitchDNSContainer(ctx, testcontainer.SnitchDNSContainerRequest{
+		ExposePorts: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start container: %v", err)
+	}
+	defer container.Terminate(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(container),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneResourceConfigWithDefaultTags(container, `["team:dns", "env:prod"]`, `["web", "env:staging"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags_all.#", "3"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags_all.*", "web"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags_all.*", "env:staging"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags_all.*", "team:dns"),
 				),
 			},
+			// Changing the defaults only updates tags_all
+			{
+				Config: testAccZoneResourceConfigWithDefaultTags(container, `["team:platform"]`, `["web", "env:staging"]`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("snitchdns_zone.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("snitchdns_zone.test", tfjsonpath.New("tags"), knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("web"),
+							knownvalue.StringExact("env:staging"),
+						})),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("snitchdns_zone.test", "tags_all.#", "3"),
+					resource.TestCheckTypeSetElemAttr("snitchdns_zone.test", "tags_all.*", "team:platform"),
+				),
+			},
+			// The applied configuration plans cleanly
+			{
+				Config:   testAccZoneResourceConfigWithDefaultTags(container, `["team:platform"]`, `["web", "env:staging"]`),
+				PlanOnly: true,
+			},
 		},
 	})
 }
@@ -149,8 +219,107 @@ resource "snitchdns_zone" "test" {
 `, container.GetAPIEndpoint(), container.APIKey, domain, tagsHCL)
 }
 
+// testAccZoneResourceConfigWithDefaultTags generates HCL configuration with provider default tags
+func testAccZoneResourceConfigWithDefaultTags(container *testcontainer.SnitchDNSContainer, defaultTags, tags string) string {
+	return fmt.Sprintf(`
+provider "snitchdns" {
+  api_url      = %[1]q
+  api_key      = %[2]q
+  default_tags = %[3]s
+}
+
+resource "snitchdns_zone" "test" {
+  domain     = "defaults.example.com"
+  active     = true
+  catch_all  = false
+  forwarding = false
+  regex      = false
+  tags       = %[4]s
+}
+`, container.GetAPIEndpoint(), container.APIKey, defaultTags, tags)
+}
+
 // testAccCheckZoneDestroy verifies the zone has been destroyed
 func testAccCheckZoneDestroy(s *terraform.State) error {
 	// This will be implemented when we have the client
 	return nil
 }
+
+// TestZoneUpgradeStateV0 tests converting list tags in version 0 state to sets
+func TestZoneUpgradeStateV0(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		null bool
+		want []string
+	}{
+		{"null", nil, true, nil},
+		{"empty", []string{}, false, []string{}},
+		{"tags", []string{"web", "production"}, false, []string{"production", "web"}},
+		{"duplicates", []string{"web", "production", "web"}, false, []string{"production", "web"}},
+	}
+
+	ctx := context.Background()
+	r := &ZoneResource{}
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Schema.Version != 1 {
+		t.Fatalf("Expected schema version 1, got %d", schemaResp.Schema.Version)
+	}
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+	stringList := tftypes.List{ElementType: tftypes.String}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]tftypes.Value{
+				"id":     tftypes.NewValue(tftypes.String, "1"),
+				"domain": tftypes.NewValue(tftypes.String, "example.com"),
+				"active": tftypes.NewValue(tftypes.Bool, true),
+			}
+			if !tt.null {
+				var elements []tftypes.Value
+				for _, tag := range tt.tags {
+					elements = append(elements, tftypes.NewValue(tftypes.String, tag))
+				}
+				raw["tags"] = tftypes.NewValue(stringList, elements)
+			}
+
+			req := fwresource.UpgradeStateRequest{
+				State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: testObject(priorType, raw)},
+			}
+			resp := &fwresource.UpgradeStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema},
+			}
+			upgrader.StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var data ZoneResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			if data.ID.ValueString() != "1" || data.Domain.ValueString() != "example.com" || !data.Active.ValueBool() {
+				t.Errorf("Expected the other attributes to be kept, got %+v", data)
+			}
+			if !data.TagsAll.IsNull() {
+				t.Errorf("Expected null tags_all, got %s", data.TagsAll)
+			}
+			if tt.null {
+				if !data.Tags.IsNull() {
+					t.Errorf("Expected null tags, got %s", data.Tags)
+				}
+				return
+			}
+			var got []string
+			data.Tags.ElementsAs(ctx, &got, false)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) || data.Tags.IsNull() {
+				t.Errorf("Expected tags %v, got %s", tt.want, data.Tags)
+			}
+		})
+	}
+}
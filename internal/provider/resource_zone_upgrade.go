@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &ZoneResource{}
+
+// zoneResourceModelV0 is the state of a zone before schema version 1, where
+// tags and tags_all were lists.
+type zoneResourceModelV0 struct {
+	ID         types.String   `tfsdk:"id"`
+	UserID     types.Int64    `tfsdk:"user_id"`
+	Domain     types.String   `tfsdk:"domain"`
+	Active     types.Bool     `tfsdk:"active"`
+	CatchAll   types.Bool     `tfsdk:"catch_all"`
+	Forwarding types.Bool     `tfsdk:"forwarding"`
+	Regex      types.Bool     `tfsdk:"regex"`
+	Master     types.Bool     `tfsdk:"master"`
+	Tags       types.List     `tfsdk:"tags"`
+	TagsAll    types.List     `tfsdk:"tags_all"`
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	UpdatedAt  types.String   `tfsdk:"updated_at"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// UpgradeState upgrades zone state from earlier schema versions.
+func (r *ZoneResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":         schema.StringAttribute{Computed: true},
+					"user_id":    schema.Int64Attribute{Computed: true},
+					"domain":     schema.StringAttribute{Required: true},
+					"active":     schema.BoolAttribute{Required: true},
+					"catch_all":  schema.BoolAttribute{Required: true},
+					"forwarding": schema.BoolAttribute{Required: true},
+					"regex":      schema.BoolAttribute{Required: true},
+					"master":     schema.BoolAttribute{Computed: true},
+					"tags":       schema.ListAttribute{ElementType: types.StringType, Optional: true},
+					"tags_all":   schema.ListAttribute{ElementType: types.StringType, Computed: true},
+					"created_at": schema.StringAttribute{Computed: true},
+					"updated_at": schema.StringAttribute{Computed: true},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.Block(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+				},
+			},
+			StateUpgrader: upgradeZoneStateV0,
+		},
+	}
+}
+
+// upgradeZoneStateV0 converts the tags and tags_all lists of a version 0
+// state to sets. The zone on the server is not touched; duplicate tags,
+// which the server could not tell apart anyway, are dropped.
+func upgradeZoneStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior zoneResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := ZoneResourceModel{
+		ID:         prior.ID,
+		UserID:     prior.UserID,
+		Domain:     prior.Domain,
+		Active:     prior.Active,
+		CatchAll:   prior.CatchAll,
+		Forwarding: prior.Forwarding,
+		Regex:      prior.Regex,
+		Master:     prior.Master,
+		CreatedAt:  prior.CreatedAt,
+		UpdatedAt:  prior.UpdatedAt,
+		Timeouts:   prior.Timeouts,
+	}
+
+	var diags diag.Diagnostics
+	data.Tags, diags = tagsListToSet(ctx, prior.Tags)
+	resp.Diagnostics.Append(diags...)
+	data.TagsAll, diags = tagsListToSet(ctx, prior.TagsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// tagsListToSet converts a list of tags to a set. Unlike tagsSetValue it
+// keeps an empty list empty, so that a configured empty list still matches
+// the state.
+func tagsListToSet(ctx context.Context, list types.List) (types.Set, diag.Diagnostics) {
+	if list.IsNull() {
+		return types.SetNull(types.StringType), nil
+	}
+	if list.IsUnknown() {
+		return types.SetUnknown(types.StringType), nil
+	}
+
+	var tags []string
+	diags := list.ElementsAs(ctx, &tags, false)
+	if diags.HasError() {
+		return types.SetNull(types.StringType), diags
+	}
+	slices.Sort(tags)
+
+	set, d := types.SetValueFrom(ctx, types.StringType, slices.Compact(tags))
+	diags.Append(d...)
+	return set, diags
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tagKey returns the key of a tag of the form key:value or key=value. Tags
+// without a separator have no key.
+func tagKey(tag string) (string, bool) {
+	i := strings.IndexAny(tag, ":=")
+	if i < 0 {
+		return "", false
+	}
+	return tag[:i], true
+}
+
+// mergeTags returns tags followed by the default tags that are neither
+// already present nor overridden by a tag with the same key
+func mergeTags(defaults, tags []string) []string {
+	merged := slices.Clone(tags)
+
+	keys := map[string]bool{}
+	for _, tag := range tags {
+		if key, ok := tagKey(tag); ok {
+			keys[key] = true
+		}
+	}
+
+	for _, tag := range defaults {
+		if slices.Contains(merged, tag) {
+			continue
+		}
+		if key, ok := tagKey(tag); ok && keys[key] {
+			continue
+		}
+		merged = append(merged, tag)
+	}
+
+	return merged
+}
+
+// resourceTags returns the part of all, the tags stored on the server, that
+// belongs to the resource's own tags. Tags that were in the resource's tags
+// before are kept. Other tags are dropped if they were in the previous
+// tagsAll, i.e. came from the default tags at the last apply, or are in the
+// current defaults. Whatever is left was added outside of Terraform and is
+// kept so that it shows up as drift.
+func resourceTags(all, defaults, prevTags, prevTagsAll []string) []string {
+	var tags []string
+	for _, tag := range all {
+		if slices.Contains(prevTags, tag) {
+			tags = append(tags, tag)
+			continue
+		}
+		if slices.Contains(prevTagsAll, tag) || slices.Contains(defaults, tag) {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// tagsSetValue converts tags to a set value, dropping duplicates. No tags are
+// null, matching an unset tags attribute.
+func tagsSetValue(ctx context.Context, tags []string) (types.Set, diag.Diagnostics) {
+	if len(tags) == 0 {
+		return types.SetNull(types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, slices.Compact(slices.Sorted(slices.Values(tags))))
+}
+
+// tagsFromSet returns the elements of a tags set. Null and unknown sets have
+// no elements.
+func tagsFromSet(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() {
+		return nil, nil
+	}
+	var tags []string
+	diags := set.ElementsAs(ctx, &tags, false)
+	return tags, diags
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestMergeTags tests merging default tags into resource tags
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults []string
+		tags     []string
+		want     []string
+	}{
+		{"no defaults", nil, []string{"web"}, []string{"web"}},
+		{"no tags", []string{"team:dns", "managed-by:terraform"}, nil, []string{"team:dns", "managed-by:terraform"}},
+		{"appended", []string{"team:dns"}, []string{"web"}, []string{"web", "team:dns"}},
+		{"duplicate", []string{"web", "team:dns"}, []string{"web"}, []string{"web", "team:dns"}},
+		{"resource wins", []string{"env:prod", "team:dns"}, []string{"env:staging"}, []string{"env:staging", "team:dns"}},
+		{"equals separator", []string{"env=prod"}, []string{"env=staging"}, []string{"env=staging"}},
+		{"mixed separators", []string{"env=prod"}, []string{"env:staging"}, []string{"env:staging"}},
+		{"plain tags never conflict", []string{"env"}, []string{"env:staging"}, []string{"env:staging", "env"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeTags(tt.defaults, tt.tags); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestResourceTags tests separating the resource's own tags from the default tags
+func TestResourceTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		all         []string
+		defaults    []string
+		prevTags    []string
+		prevTagsAll []string
+		want        []string
+	}{
+		{
+			name:        "unchanged",
+			all:         []string{"web", "team:dns"},
+			defaults:    []string{"team:dns"},
+			prevTags:    []string{"web"},
+			prevTagsAll: []string{"web", "team:dns"},
+			want:        []string{"web"},
+		},
+		{
+			name:        "defaults changed since last apply",
+			all:         []string{"web", "env:prod"},
+			defaults:    []string{"env:staging"},
+			prevTags:    []string{"web"},
+			prevTagsAll: []string{"web", "env:prod"},
+			want:        []string{"web"},
+		},
+		{
+			name:        "resource tag equal to a default",
+			all:         []string{"team:dns"},
+			defaults:    []string{"team:dns"},
+			prevTags:    []string{"team:dns"},
+			prevTagsAll: []string{"team:dns"},
+			want:        []string{"team:dns"},
+		},
+		{
+			name:        "added outside of terraform",
+			all:         []string{"web", "team:dns", "manual"},
+			defaults:    []string{"team:dns"},
+			prevTags:    []string{"web"},
+			prevTagsAll: []string{"web", "team:dns"},
+			want:        []string{"web", "manual"},
+		},
+		{
+			name:     "import",
+			all:      []string{"web", "team:dns"},
+			defaults: []string{"team:dns"},
+			want:     []string{"web"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resourceTags(tt.all, tt.defaults, tt.prevTags, tt.prevTagsAll); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestZoneSetTags tests that changing the default tags leaves the zone's own tags untouched
+func TestZoneSetTags(t *testing.T) {
+	ctx := context.Background()
+	r := &ZoneResource{defaultTags: []string{"team:dns", "env:staging"}}
+
+	data := ZoneResourceModel{
+		Tags:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")}),
+		TagsAll: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web"), types.StringValue("env:prod")}),
+	}
+	if diags := r.setTags(ctx, &data, []string{"web", "env:prod"}); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+
+	wantTags := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web")})
+	if !data.Tags.Equal(wantTags) {
+		t.Errorf("Expected tags %s, got %s", wantTags, data.Tags)
+	}
+	wantTagsAll := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web"), types.StringValue("env:prod")})
+	if !data.TagsAll.Equal(wantTagsAll) {
+		t.Errorf("Expected tags_all %s, got %s", wantTagsAll, data.TagsAll)
+	}
+
+	// Without any tags both attributes are null
+	data = ZoneResourceModel{Tags: types.SetNull(types.StringType), TagsAll: types.SetNull(types.StringType)}
+	if diags := r.setTags(ctx, &data, nil); diags.HasError() {
+		t.Fatalf("Unexpected error: %v", diags)
+	}
+	if !data.Tags.IsNull() || !data.TagsAll.IsNull() {
+		t.Errorf("Expected null tags and tags_all, got %s and %s", data.Tags, data.TagsAll)
+	}
+}
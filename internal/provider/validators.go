@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Ensure validator types fully satisfy framework interfaces.
+var _ validator.String = ipOrCIDRValidator{}
+
+// ipOrCIDRValidator validates that a string is a single IP address or a CIDR range.
+type ipOrCIDRValidator struct{}
+
+// ipOrCIDR returns a validator which accepts IPv4/IPv6 addresses and CIDR ranges.
+func ipOrCIDR() validator.String {
+	return ipOrCIDRValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v ipOrCIDRValidator) Description(_ context.Context) string {
+	return "value must be an IP address or a CIDR range such as 192.168.0.0/24"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v ipOrCIDRValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v ipOrCIDRValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseIPOrCIDR(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP Address or CIDR Range",
+			fmt.Sprintf("%s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// parseIPOrCIDR parses a single address or a CIDR range into a prefix. Single
+// addresses become a host prefix (/32 or /128).
+func parseIPOrCIDR(value string) (netip.Prefix, error) {
+	if strings.Contains(value, "/") {
+		return netip.ParsePrefix(value)
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// Ensure validator types fully satisfy framework interfaces.
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a Go duration such as "30s"
+// of at least minimum.
+type durationValidator struct {
+	minimum time.Duration
+}
+
+// durationAtLeast returns a validator which accepts durations such as "30s"
+// or "1m30s" that are at least minimum.
+func durationAtLeast(minimum time.Duration) validator.String {
+	return durationValidator{minimum: minimum}
+}
+
+// Description describes the validation in plain text formatting.
+func (v durationValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a duration such as 30s or 1m30s of at least %s", v.minimum)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseDurationAtLeast(req.ConfigValue.ValueString(), v.minimum); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// parseDurationAtLeast parses a duration and checks that it is at least
+// minimum.
+func parseDurationAtLeast(value string, minimum time.Duration) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < minimum {
+		return 0, fmt.Errorf("must be at least %s", minimum)
+	}
+	return d, nil
+}
+
+// Ensure validator types fully satisfy framework interfaces.
+var _ validator.String = urlValidator{}
+
+// urlValidator validates that a string is an absolute URL with one of the
+// allowed schemes.
+type urlValidator struct {
+	schemes []string
+}
+
+// urlWithScheme returns a validator which accepts absolute URLs with a host
+// and one of the given schemes.
+func urlWithScheme(schemes ...string) validator.String {
+	return urlValidator{schemes: schemes}
+}
+
+// Description describes the validation in plain text formatting.
+func (v urlValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be an absolute URL with scheme %s", strings.Join(v.schemes, ", "))
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v urlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v urlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseURLWithScheme(req.ConfigValue.ValueString(), v.schemes...); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("%s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// parseURLWithScheme parses an absolute URL and checks that it has a host
+// and one of the given schemes.
+func parseURLWithScheme(value string, schemes ...string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(schemes, strings.ToLower(u.Scheme)) {
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing host")
+	}
+	return u, nil
+}
+
+// Ensure validator types fully satisfy framework interfaces.
+var _ validator.String = tagValidator{}
+
+// tagValidator validates that a string can be stored as a zone tag.
+type tagValidator struct{}
+
+// zoneTag returns a validator which accepts non-empty tags without commas or
+// surrounding whitespace. The API stores tags as a comma-separated string,
+// so a comma would split a tag into two.
+func zoneTag() validator.String {
+	return tagValidator{}
+}
+
+// Description describes the validation in plain text formatting.
+func (v tagValidator) Description(_ context.Context) string {
+	return "value must be a non-empty tag without commas or surrounding whitespace"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v tagValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v tagValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := checkTag(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Tag",
+			fmt.Sprintf("%s, got %q: %s", v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+// checkTag checks that a tag survives the round trip through the API's
+// comma-separated tags string unchanged.
+func checkTag(value string) error {
+	switch {
+	case value == "":
+		return fmt.Errorf("must not be empty")
+	case strings.Contains(value, ","):
+		return fmt.Errorf("must not contain a comma")
+	case strings.TrimSpace(value) != value:
+		return fmt.Errorf("must not start or end with whitespace")
+	}
+	return nil
+}
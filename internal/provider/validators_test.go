@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestIPOrCIDRValidator tests accepted and rejected IP addresses and ranges
+func TestIPOrCIDRValidator(t *testing.T) {
+	tests := []struct {
+		value     types.String
+		wantError bool
+	}{
+		{types.StringValue("192.168.0.1"), false},
+		{types.StringValue("192.168.0.0/24"), false},
+		{types.StringValue("2001:db8::/32"), false},
+		{types.StringValue("::1"), false},
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+		{types.StringValue("192.168.0.0/33"), true},
+		{types.StringValue("300.1.1.1"), true},
+		{types.StringValue("example.com"), true},
+		{types.StringValue(""), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("ip_or_range"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			ipOrCIDR().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error=%t, got diagnostics: %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestTagValidator tests accepted and rejected tags
+func TestTagValidator(t *testing.T) {
+	tests := []struct {
+		value     types.String
+		wantError bool
+	}{
+		{types.StringValue("production"), false},
+		{types.StringValue("team:dns"), false},
+		{types.StringValue("owner=dns team"), false},
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+		{types.StringValue(""), true},
+		{types.StringValue("web,prod"), true},
+		{types.StringValue(" web"), true},
+		{types.StringValue("web\n"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("tags"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			zoneTag().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error=%t, got diagnostics: %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}